@@ -1,11 +1,39 @@
 package main
 
+import "time"
+
 type Config struct {
-	JobCron            string
-	ConnectionString   string
-	CurrencyApiBaseUrl string
-	Currencies         string
-	DaysLookBack       int
-	AppPort            int
-	ApiKey             string
+	JobCron                 string
+	ConnectionString        string
+	AutoMigrate             bool
+	CurrencyApiBaseUrl      string
+	EcbBaseUrl              string
+	RateSources             string
+	RateSourceMode          string
+	ConsensusTolerance      float64
+	Currencies              string
+	PivotCurrencies         string
+	DaysLookBack            int
+	RetentionDays           int
+	FinalizeAfterDays       int
+	GapFillCron             string
+	CatalogReloadCron       string
+	MaxStalenessDays        int
+	AppPort                 int
+	ApiKey                  string
+	AdminApiKey             string
+	SyncMaxAttempts         int
+	SyncInitialBackoff      time.Duration
+	SyncMaxBackoff          time.Duration
+	SyncBackoffJitter       float64
+	SyncConcurrency         int
+	SourceRateLimit         float64
+	SyncFetchBase           string
+	CrossRateTolerance      float64
+	AnomalyFlagChange       float64
+	AnomalyQuarantineChange float64
+	AnomalyLookbackDays     int
+	CacheSize               int
+	CacheTodayTTL           time.Duration
+	CachePastTTL            time.Duration
 }
@@ -3,20 +3,21 @@ package main
 import (
 	"context"
 	"log"
+	"net/url"
 	"os"
 	"os/signal"
 	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/fedorov-dmitry/go-test-api/internal"
 	"github.com/fedorov-dmitry/go-test-api/internal/api"
+	"github.com/fedorov-dmitry/go-test-api/internal/ecbeuropa"
 	"github.com/fedorov-dmitry/go-test-api/internal/jsdelivrnet"
 	"github.com/fedorov-dmitry/go-test-api/internal/logging"
 	"github.com/fedorov-dmitry/go-test-api/internal/middleware"
-	"github.com/fedorov-dmitry/go-test-api/internal/postgresql"
 	"github.com/go-co-op/gocron/v2"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 func main() {
@@ -28,26 +29,87 @@ func main() {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		store, err := newStorage(ctx, os.Getenv("CONNECTION_STRING"))
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		err = runMigrate(ctx, store.migrator, os.Args[2:])
+		if err != nil {
+			log.Fatalf("failed to migrate database: %v", err)
+		}
+		return
+	}
+
 	cfg := LoadConfig()
 
-	pgxPool, err := pgxpool.New(ctx, cfg.ConnectionString)
+	store, err := newStorage(ctx, cfg.ConnectionString)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if cfg.AutoMigrate && store.migrator != nil {
+		err = runMigrate(ctx, store.migrator, nil)
+		if err != nil {
+			log.Fatalf("failed to migrate database: %v", err)
+		}
+	}
+
+	catalog := internal.NewCurrencyCatalog(store.currencies)
+	err = catalog.Load(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = validateCurrencies(catalog, cfg)
 	if err != nil {
-		log.Fatalf("failed to create postgresql pool: %v", err)
+		log.Fatalf("invalid currency configuration: %v", err)
 	}
 
-	storage := postgresql.NewCurrencyStorage(pgxPool)
-	repository := internal.NewCurrencyRepository(storage)
-	currencyRateSource := jsdelivrnet.NewCurrencyRateSource(cfg.CurrencyApiBaseUrl)
+	cache := internal.NewCachedCurrencyStorage(store.rates, cfg.CacheSize, cfg.CacheTodayTTL, cfg.CachePastTTL)
+	repository := internal.NewCurrencyRepository(cache, parseCurrencies(cfg.PivotCurrencies)...)
+	currencyRateSource := newCurrencyRateSource(cfg)
+
+	currencies := parseCurrencies(cfg.Currencies)
+	syncRunRepository := internal.NewSyncRunRepository(store.syncRuns)
+	quarantineRepository := internal.NewQuarantineRepository(store.quarantine, repository)
+
+	trackedCurrencies := internal.NewTrackedCurrencyRepository(store.tracked, catalog)
+	err = trackedCurrencies.Seed(ctx, currencies)
+	if err != nil {
+		log.Fatalf("failed to seed tracked currencies: %v", err)
+	}
 
-	currencyStrings := strings.Split(strings.ToLower(cfg.Currencies), ",")
-	currencies := make([]internal.Currency, len(currencyStrings))
-	for i, c := range currencyStrings {
-		currencies[i] = internal.NewCurrency(c)
+	apiKeys := internal.NewApiKeyRepository(store.apiKeys)
+	storedKeys, err := apiKeys.List(ctx)
+	if err != nil {
+		log.Fatalf("failed to list api keys: %v", err)
 	}
 
-	currencySynchronizer := internal.NewCurrencySynchronizer(*repository, currencyRateSource, currencies)
+	currencySynchronizer := internal.NewCurrencySynchronizer(*repository, currencyRateSource, currencies,
+		internal.WithRetryPolicy(internal.RetryPolicy{
+			MaxAttempts:    cfg.SyncMaxAttempts,
+			InitialBackoff: cfg.SyncInitialBackoff,
+			MaxBackoff:     cfg.SyncMaxBackoff,
+			Multiplier:     2,
+			Jitter:         cfg.SyncBackoffJitter,
+			Retryable:      internal.IsRetryable,
+		}),
+		internal.WithConcurrency(cfg.SyncConcurrency),
+		internal.WithRunHistory(syncRunRepository),
+		internal.WithRetentionDays(cfg.RetentionDays),
+		internal.WithFinalizeAfterDays(cfg.FinalizeAfterDays),
+		internal.WithSingleFetch(internal.NewCurrency(cfg.SyncFetchBase), cfg.CrossRateTolerance),
+		internal.WithAnomalyDetection(internal.AnomalyPolicy{
+			FlagChange:       cfg.AnomalyFlagChange,
+			QuarantineChange: cfg.AnomalyQuarantineChange,
+			LookbackDays:     cfg.AnomalyLookbackDays,
+		}, quarantineRepository),
+		internal.WithTrackedCurrencies(trackedCurrencies),
+	)
 
-	err = currencySynchronizer.UpdateCurrencyRatesForTodayAndLastNDays(ctx, cfg.DaysLookBack)
+	err = currencySynchronizer.Sync(ctx, internal.SyncTriggerStartup, cfg.DaysLookBack)
 	if err != nil {
 		log.Printf("failed to save currency rates for last %v days: %v", cfg.DaysLookBack, err)
 	}
@@ -59,20 +121,67 @@ func main() {
 	} else {
 		_, err := s.NewJob(
 			gocron.CronJob(cfg.JobCron, false),
-			gocron.NewTask(currencySynchronizer.UpdateCurrencyRatesForTodayAndLastNDays, ctx, 0),
+			gocron.NewTask(currencySynchronizer.Sync, ctx, internal.SyncTriggerSchedule, 0),
 		)
 
 		if err != nil {
 			log.Printf("failed to start currency rate synchronizer: %v\n", err)
 		}
 
+		_, err = s.NewJob(
+			gocron.CronJob(cfg.GapFillCron, false),
+			gocron.NewTask(func() {
+				err := currencySynchronizer.FillGaps(ctx)
+				if err != nil {
+					log.Printf("failed to fill currency rate gaps for last %v days: %v", cfg.RetentionDays, err)
+				}
+			}),
+		)
+
+		if err != nil {
+			log.Printf("failed to start currency rate gap filler: %v\n", err)
+		}
+
+		_, err = s.NewJob(
+			gocron.CronJob(cfg.CatalogReloadCron, false),
+			gocron.NewTask(func() {
+				err := catalog.Reload(ctx)
+				if err != nil {
+					log.Printf("failed to reload currency catalog: %v", err)
+				}
+			}),
+		)
+
+		if err != nil {
+			log.Printf("failed to start currency catalog reloader: %v\n", err)
+		}
+
 		s.Start()
 	}
 
 	logCh := make(chan middleware.RequestLog, 1000)
-	logging.StartLoggingWorker(ctx, pgxPool, logCh)
+	logging.StartLoggingWorker(ctx, store.requestLogs, logCh)
+
+	serverOptions := []api.ServerOption{
+		api.WithMaxStalenessDays(cfg.MaxStalenessDays),
+		api.WithSyncRuns(syncRunRepository),
+		api.WithAdminApiKey(cfg.AdminApiKey),
+		api.WithQuarantine(quarantineRepository),
+		api.WithCache(cache),
+		api.WithCurrencyCatalog(catalog),
+		api.WithTrackedCurrencies(trackedCurrencies),
+	}
+
+	if cfg.ApiKey != "" || cfg.AdminApiKey != "" || len(storedKeys) > 0 {
+		serverOptions = append(serverOptions, api.WithApiKeys(apiKeys))
+		if cfg.ApiKey == "" {
+			log.Println("API_KEY is not set, rate endpoints only accept ADMIN_API_KEY or stored api keys with the rates:read scope")
+		}
+	} else {
+		log.Println("no API_KEY, ADMIN_API_KEY or stored api keys configured, rate endpoints are open and admin endpoints are disabled")
+	}
 
-	server := api.NewServer(repository, *currencySynchronizer, ctx, logCh, cfg.AppPort, cfg.ApiKey)
+	server := api.NewServer(repository, *currencySynchronizer, ctx, logCh, cfg.AppPort, cfg.ApiKey, serverOptions...)
 
 	err = server.Start()
 	if err != nil {
@@ -94,6 +203,70 @@ func main() {
 	log.Println("currency rate synchronizer scheduler stopped cleanly")
 }
 
+func newCurrencyRateSource(cfg Config) internal.CurrencyRateSource {
+	switch strings.ToLower(cfg.RateSourceMode) {
+	case "fallback":
+		return internal.NewCompositeCurrencyRateSource(newRateSources(cfg)...)
+	case "consensus":
+		return internal.NewConsensusCurrencyRateSource(cfg.ConsensusTolerance, newRateSources(cfg)...)
+	default:
+		log.Fatalf("unknown RATE_SOURCE_MODE: %q", cfg.RateSourceMode)
+		return nil
+	}
+}
+
+func newRateSources(cfg Config) []internal.CurrencyRateSource {
+	rateSources := make([]internal.CurrencyRateSource, 0)
+	limiter := internal.NewHostRateLimiter(cfg.SourceRateLimit)
+
+	for _, name := range strings.Split(strings.ToLower(cfg.RateSources), ",") {
+		switch strings.TrimSpace(name) {
+		case "jsdelivr":
+			for _, baseURL := range strings.Split(cfg.CurrencyApiBaseUrl, ",") {
+				baseURL = strings.TrimSpace(baseURL)
+				source := jsdelivrnet.NewCurrencyRateSource(baseURL)
+				rateSources = append(rateSources, internal.NewRateLimitedCurrencyRateSource(source, limiter, urlHost(baseURL)))
+			}
+		case "ecb":
+			source := ecbeuropa.NewCurrencyRateSource(cfg.EcbBaseUrl)
+			rateSources = append(rateSources, internal.NewRateLimitedCurrencyRateSource(source, limiter, urlHost(cfg.EcbBaseUrl)))
+		default:
+			log.Fatalf("unknown rate source in RATE_SOURCES: %q", name)
+		}
+	}
+
+	return rateSources
+}
+
+func urlHost(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+
+	return u.Host
+}
+
+func validateCurrencies(catalog *internal.CurrencyCatalog, cfg Config) error {
+	currencies := parseCurrencies(cfg.Currencies)
+	currencies = append(currencies, parseCurrencies(cfg.PivotCurrencies)...)
+	currencies = append(currencies, parseCurrencies(cfg.SyncFetchBase)...)
+
+	return catalog.Validate(currencies...)
+}
+
+func parseCurrencies(str string) []internal.Currency {
+	currencies := make([]internal.Currency, 0)
+	for _, c := range strings.Split(str, ",") {
+		c = strings.TrimSpace(c)
+		if c != "" {
+			currencies = append(currencies, internal.NewCurrency(c))
+		}
+	}
+
+	return currencies
+}
+
 func LoadConfig() Config {
 	cfg := Config{}
 
@@ -102,27 +275,151 @@ func LoadConfig() Config {
 		log.Fatalf("failed to parse DAYS_LOOK_BACK env var: %v", err)
 	}
 
-	jobCron := os.Getenv("JOB_CRON")
-	if jobCron == "" {
-		jobCron = "* * * * *"
+	autoMigrate, err := strconv.ParseBool(getEnvOrDefault("AUTO_MIGRATE", "true"))
+	if err != nil {
+		log.Fatalf("failed to parse AUTO_MIGRATE env var: %v", err)
+	}
+
+	retentionDays, err := strconv.Atoi(getEnvOrDefault("RETENTION_DAYS", "365"))
+	if err != nil {
+		log.Fatalf("failed to parse RETENTION_DAYS env var: %v", err)
+	}
+
+	finalizeAfterDays, err := strconv.Atoi(getEnvOrDefault("SYNC_FINALIZE_AFTER_DAYS", "1"))
+	if err != nil {
+		log.Fatalf("failed to parse SYNC_FINALIZE_AFTER_DAYS env var: %v", err)
+	}
+
+	maxStalenessDays, err := strconv.Atoi(getEnvOrDefault("MAX_STALENESS_DAYS", "3"))
+	if err != nil {
+		log.Fatalf("failed to parse MAX_STALENESS_DAYS env var: %v", err)
+	}
+	if maxStalenessDays < 0 {
+		log.Fatalf("invalid MAX_STALENESS_DAYS env var: %d, must not be negative", maxStalenessDays)
+	}
+
+	syncMaxAttempts, err := strconv.Atoi(getEnvOrDefault("SYNC_MAX_ATTEMPTS", "3"))
+	if err != nil {
+		log.Fatalf("failed to parse SYNC_MAX_ATTEMPTS env var: %v", err)
+	}
+
+	syncInitialBackoff, err := time.ParseDuration(getEnvOrDefault("SYNC_INITIAL_BACKOFF", "1s"))
+	if err != nil {
+		log.Fatalf("failed to parse SYNC_INITIAL_BACKOFF env var: %v", err)
+	}
+
+	syncMaxBackoff, err := time.ParseDuration(getEnvOrDefault("SYNC_MAX_BACKOFF", "30s"))
+	if err != nil {
+		log.Fatalf("failed to parse SYNC_MAX_BACKOFF env var: %v", err)
+	}
+
+	syncBackoffJitter, err := strconv.ParseFloat(getEnvOrDefault("SYNC_BACKOFF_JITTER", "0.2"), 64)
+	if err != nil {
+		log.Fatalf("failed to parse SYNC_BACKOFF_JITTER env var: %v", err)
+	}
+
+	syncConcurrency, err := strconv.Atoi(getEnvOrDefault("SYNC_CONCURRENCY", "4"))
+	if err != nil {
+		log.Fatalf("failed to parse SYNC_CONCURRENCY env var: %v", err)
+	}
+
+	sourceRateLimit, err := strconv.ParseFloat(getEnvOrDefault("SOURCE_RATE_LIMIT", "10"), 64)
+	if err != nil {
+		log.Fatalf("failed to parse SOURCE_RATE_LIMIT env var: %v", err)
 	}
 
-	appPortStr := os.Getenv("APP_PORT")
-	if appPortStr == "" {
-		appPortStr = "8080"
+	consensusTolerance, err := strconv.ParseFloat(getEnvOrDefault("CONSENSUS_TOLERANCE", "0.01"), 64)
+	if err != nil {
+		log.Fatalf("failed to parse CONSENSUS_TOLERANCE env var: %v", err)
+	}
+
+	anomalyFlagChange, err := strconv.ParseFloat(getEnvOrDefault("ANOMALY_FLAG_CHANGE", "0.05"), 64)
+	if err != nil {
+		log.Fatalf("failed to parse ANOMALY_FLAG_CHANGE env var: %v", err)
 	}
-	appPort, err := strconv.Atoi(appPortStr)
+
+	anomalyQuarantineChange, err := strconv.ParseFloat(getEnvOrDefault("ANOMALY_QUARANTINE_CHANGE", "0.5"), 64)
+	if err != nil {
+		log.Fatalf("failed to parse ANOMALY_QUARANTINE_CHANGE env var: %v", err)
+	}
+
+	anomalyLookbackDays, err := strconv.Atoi(getEnvOrDefault("ANOMALY_LOOKBACK_DAYS", "7"))
+	if err != nil {
+		log.Fatalf("failed to parse ANOMALY_LOOKBACK_DAYS env var: %v", err)
+	}
+
+	cacheSize, err := strconv.Atoi(getEnvOrDefault("CACHE_SIZE", "10000"))
+	if err != nil {
+		log.Fatalf("failed to parse CACHE_SIZE env var: %v", err)
+	}
+
+	cacheTodayTTL, err := time.ParseDuration(getEnvOrDefault("CACHE_TODAY_TTL", "1m"))
+	if err != nil {
+		log.Fatalf("failed to parse CACHE_TODAY_TTL env var: %v", err)
+	}
+
+	cachePastTTL, err := time.ParseDuration(getEnvOrDefault("CACHE_PAST_TTL", "24h"))
+	if err != nil {
+		log.Fatalf("failed to parse CACHE_PAST_TTL env var: %v", err)
+	}
+
+	crossRateTolerance, err := strconv.ParseFloat(getEnvOrDefault("CROSS_RATE_TOLERANCE", "0.005"), 64)
+	if err != nil {
+		log.Fatalf("failed to parse CROSS_RATE_TOLERANCE env var: %v", err)
+	}
+
+	pivotCurrencies, ok := os.LookupEnv("PIVOT_CURRENCIES")
+	if !ok {
+		pivotCurrencies = "EUR,USD"
+	}
+
+	appPort, err := strconv.Atoi(getEnvOrDefault("APP_PORT", "8080"))
 	if err != nil {
 		log.Fatalf("failed to parse APP_PORT env var: %v", err)
 	}
 
 	cfg.ConnectionString = os.Getenv("CONNECTION_STRING")
+	cfg.AutoMigrate = autoMigrate
 	cfg.CurrencyApiBaseUrl = os.Getenv("API_BASE_URL")
+	cfg.EcbBaseUrl = getEnvOrDefault("ECB_BASE_URL", "https://www.ecb.europa.eu/stats/eurofxref")
+	cfg.RateSources = getEnvOrDefault("RATE_SOURCES", "jsdelivr")
+	cfg.RateSourceMode = getEnvOrDefault("RATE_SOURCE_MODE", "fallback")
+	cfg.ConsensusTolerance = consensusTolerance
 	cfg.Currencies = os.Getenv("CURRENCIES")
+	cfg.PivotCurrencies = pivotCurrencies
 	cfg.DaysLookBack = daysLookBack
-	cfg.JobCron = jobCron
+	cfg.RetentionDays = retentionDays
+	cfg.FinalizeAfterDays = finalizeAfterDays
+	cfg.GapFillCron = getEnvOrDefault("GAP_FILL_CRON", "0 * * * *")
+	cfg.CatalogReloadCron = getEnvOrDefault("CATALOG_RELOAD_CRON", "*/5 * * * *")
+	cfg.MaxStalenessDays = maxStalenessDays
+	cfg.JobCron = getEnvOrDefault("JOB_CRON", "* * * * *")
 	cfg.AppPort = appPort
 	cfg.ApiKey = os.Getenv("API_KEY")
+	cfg.AdminApiKey = os.Getenv("ADMIN_API_KEY")
+	cfg.SyncMaxAttempts = syncMaxAttempts
+	cfg.SyncInitialBackoff = syncInitialBackoff
+	cfg.SyncMaxBackoff = syncMaxBackoff
+	cfg.SyncBackoffJitter = syncBackoffJitter
+	cfg.SyncConcurrency = syncConcurrency
+	cfg.SourceRateLimit = sourceRateLimit
+	cfg.SyncFetchBase = os.Getenv("SYNC_FETCH_BASE")
+	cfg.CrossRateTolerance = crossRateTolerance
+	cfg.AnomalyFlagChange = anomalyFlagChange
+	cfg.AnomalyQuarantineChange = anomalyQuarantineChange
+	cfg.AnomalyLookbackDays = anomalyLookbackDays
+	cfg.CacheSize = cacheSize
+	cfg.CacheTodayTTL = cacheTodayTTL
+	cfg.CachePastTTL = cachePastTTL
 
 	return cfg
 }
+
+func getEnvOrDefault(key string, defaultValue string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	return value
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/fedorov-dmitry/go-test-api/internal/migration"
+)
+
+const migrateUsage = "usage: test-api-go migrate [up | down [N] | status]"
+
+type migrator interface {
+	Up(ctx context.Context) ([]migration.Migration, error)
+	Down(ctx context.Context, steps int) ([]migration.Migration, error)
+	Status(ctx context.Context) ([]migration.Status, error)
+}
+
+func runMigrate(ctx context.Context, migrator migrator, args []string) error {
+	if migrator == nil {
+		return errors.New("in-memory storage has no schema to migrate")
+	}
+
+	command := "up"
+	if len(args) > 0 {
+		command = args[0]
+	}
+
+	switch command {
+	case "up":
+		if len(args) > 1 {
+			return errors.New(migrateUsage)
+		}
+
+		applied, err := migrator.Up(ctx)
+		for _, migration := range applied {
+			log.Printf("applied migration %04d_%s", migration.Version, migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+
+		if len(applied) == 0 {
+			log.Println("database schema is up to date")
+		}
+	case "down":
+		steps := 1
+		if len(args) > 2 {
+			return errors.New(migrateUsage)
+		}
+		if len(args) == 2 {
+			var err error
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("invalid number of migrations to revert %q: %s", args[1], migrateUsage)
+			}
+		}
+
+		reverted, err := migrator.Down(ctx, steps)
+		for _, migration := range reverted {
+			log.Printf("reverted migration %04d_%s", migration.Version, migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+	case "status":
+		if len(args) > 1 {
+			return errors.New(migrateUsage)
+		}
+
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = "applied at " + status.AppliedAt.UTC().Format("2006-01-02 15:04:05")
+			}
+			log.Printf("%04d_%s: %s", status.Version, status.Name, appliedAt)
+		}
+	default:
+		return fmt.Errorf("unknown migrate command %q: %s", command, migrateUsage)
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/fedorov-dmitry/go-test-api/internal"
+	"github.com/fedorov-dmitry/go-test-api/internal/logging"
+	"github.com/fedorov-dmitry/go-test-api/internal/memory"
+	"github.com/fedorov-dmitry/go-test-api/internal/postgresql"
+	"github.com/fedorov-dmitry/go-test-api/internal/sqlite"
+)
+
+const (
+	memoryScheme = "memory://"
+	sqliteScheme = "sqlite://"
+)
+
+type storage struct {
+	rates       internal.CurrencyStorage
+	syncRuns    internal.SyncRunStorage
+	quarantine  internal.QuarantineStorage
+	currencies  internal.CurrencyCatalogStorage
+	tracked     internal.TrackedCurrencyStorage
+	apiKeys     internal.ApiKeyStorage
+	requestLogs logging.RequestLogStorage
+	migrator    migrator
+}
+
+func newStorage(ctx context.Context, connectionString string) (storage, error) {
+	if strings.HasPrefix(connectionString, memoryScheme) {
+		rates := memory.NewCurrencyStorage()
+
+		return storage{
+			rates:       rates,
+			syncRuns:    memory.NewSyncRunStorage(),
+			quarantine:  memory.NewQuarantineStorage(rates),
+			currencies:  memory.NewCurrencyCatalogStorage(),
+			tracked:     memory.NewTrackedCurrencyStorage(),
+			apiKeys:     memory.NewApiKeyStorage(),
+			requestLogs: memory.NewRequestLogStorage(),
+		}, nil
+	}
+
+	if path, ok := strings.CutPrefix(connectionString, sqliteScheme); ok {
+		db, err := sqlite.Open(path)
+		if err != nil {
+			return storage{}, err
+		}
+
+		migrator, err := sqlite.NewMigrator(db)
+		if err != nil {
+			return storage{}, fmt.Errorf("failed to load migrations: %w", err)
+		}
+
+		return storage{
+			rates:       sqlite.NewCurrencyStorage(db),
+			syncRuns:    sqlite.NewSyncRunStorage(db),
+			quarantine:  sqlite.NewQuarantineStorage(db),
+			currencies:  sqlite.NewCurrencyCatalogStorage(db),
+			tracked:     sqlite.NewTrackedCurrencyStorage(db),
+			apiKeys:     sqlite.NewApiKeyStorage(db),
+			requestLogs: sqlite.NewRequestLogStorage(db),
+			migrator:    migrator,
+		}, nil
+	}
+
+	pgxPool, err := postgresql.NewPool(ctx, connectionString)
+	if err != nil {
+		return storage{}, err
+	}
+
+	migrator, err := postgresql.NewMigrator(pgxPool)
+	if err != nil {
+		return storage{}, fmt.Errorf("failed to load migrations: %w", err)
+	}
+
+	return storage{
+		rates:       postgresql.NewCurrencyStorage(pgxPool),
+		syncRuns:    postgresql.NewSyncRunStorage(pgxPool),
+		quarantine:  postgresql.NewQuarantineStorage(pgxPool),
+		currencies:  postgresql.NewCurrencyCatalogStorage(pgxPool),
+		tracked:     postgresql.NewTrackedCurrencyStorage(pgxPool),
+		apiKeys:     postgresql.NewApiKeyStorage(pgxPool),
+		requestLogs: postgresql.NewRequestLogStorage(pgxPool),
+		migrator:    migrator,
+	}, nil
+}
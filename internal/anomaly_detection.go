@@ -0,0 +1,152 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/shopspring/decimal"
+)
+
+type AnomalyPolicy struct {
+	FlagChange       float64
+	QuarantineChange float64
+	LookbackDays     int
+}
+
+func WithAnomalyDetection(policy AnomalyPolicy, quarantine *QuarantineRepository) SynchronizerOption {
+	return func(c *CurrencySynchronizer) {
+		c.anomalyPolicy = policy
+		c.quarantine = quarantine
+	}
+}
+
+func (c *CurrencySynchronizer) saveAll(ctx context.Context, rates []CurrencyRate) ([]bool, error) {
+	accepted, err := c.screenAll(ctx, rates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check currency rates: %w", err)
+	}
+
+	toSave := make([]CurrencyRate, 0, len(rates))
+	for i, rate := range rates {
+		if accepted[i] {
+			toSave = append(toSave, rate)
+		}
+	}
+
+	policy := c.retryPolicy
+	policy.Retryable = notPermanent
+
+	err = policy.Do(ctx, func() error {
+		return c.repository.SaveMany(ctx, toSave)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return accepted, nil
+}
+
+func (c *CurrencySynchronizer) screenAll(ctx context.Context, rates []CurrencyRate) ([]bool, error) {
+	accepted := make([]bool, len(rates))
+	for i := range accepted {
+		accepted[i] = true
+	}
+
+	if c.anomalyPolicy.FlagChange <= 0 && c.anomalyPolicy.QuarantineChange <= 0 || c.anomalyPolicy.LookbackDays <= 0 {
+		return accepted, nil
+	}
+
+	type baseDate struct {
+		base Currency
+		date string
+	}
+
+	previous := make(map[baseDate]map[Currency]CurrencyRate)
+	stored := make(map[baseDate]map[Currency]CurrencyRate)
+	for i, rate := range rates {
+		key := baseDate{base: rate.Base, date: dayKey(rate.Date)}
+
+		if _, ok := previous[key]; !ok {
+			var err error
+			previous[key], err = c.repository.GetPrevious(ctx, rate.Base, rate.Date, c.anomalyPolicy.LookbackDays)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		prev, ok := previous[key][rate.Currency]
+		if !ok {
+			continue
+		}
+
+		// an approved quarantined rate is stored for its own date, so refetching it must not quarantine it again
+		if c.quarantines(rateChange(rate, prev)) {
+			if _, ok := stored[key]; !ok {
+				current, err := c.repository.GetMany(ctx, rate.Base, rate.Date)
+				if err != nil {
+					return nil, err
+				}
+
+				stored[key] = make(map[Currency]CurrencyRate, len(current))
+				for _, r := range current {
+					stored[key][r.Currency] = r
+				}
+			}
+
+			if existing, ok := stored[key][rate.Currency]; ok && existing.Rate.Equal(rate.Rate) {
+				continue
+			}
+		}
+
+		var err error
+		accepted[i], err = c.screen(ctx, rate, prev)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check currency rate %s-%s: %w", rate.Base, rate.Currency, err)
+		}
+	}
+
+	return accepted, nil
+}
+
+func rateChange(rate CurrencyRate, previous CurrencyRate) float64 {
+	if !previous.Rate.IsPositive() || !rate.Rate.IsPositive() {
+		return 0
+	}
+
+	return decimal.Max(rate.Rate.Div(previous.Rate), previous.Rate.Div(rate.Rate)).Sub(decimal.NewFromInt(1)).InexactFloat64()
+}
+
+func (c *CurrencySynchronizer) quarantines(change float64) bool {
+	return c.anomalyPolicy.QuarantineChange > 0 && change > c.anomalyPolicy.QuarantineChange && c.quarantine != nil
+}
+
+func (c *CurrencySynchronizer) screen(ctx context.Context, rate CurrencyRate, previous CurrencyRate) (bool, error) {
+	change := rateChange(rate, previous)
+
+	if c.quarantines(change) {
+		quarantined, err := c.quarantine.Add(ctx, rate, previous, change)
+		if err != nil {
+			return false, err
+		}
+
+		if quarantined.Status == QuarantineStatusRejected {
+			log.Printf("skipped %s-%s for %s: %v was rejected as #%d",
+				rate.Base, rate.Currency, rate.Date.Format("2006-01-02"), rate.Rate, quarantined.ID)
+
+			return false, nil
+		}
+
+		log.Printf("quarantined %s-%s for %s as #%d: %v moved %.2f%% from %v on %s",
+			rate.Base, rate.Currency, rate.Date.Format("2006-01-02"), quarantined.ID, rate.Rate, change*100, previous.Rate, previous.Date.Format("2006-01-02"))
+
+		return false, nil
+	}
+
+	if c.anomalyPolicy.FlagChange > 0 && change > c.anomalyPolicy.FlagChange {
+		log.Printf("flagged %s-%s for %s: %v moved %.2f%% from %v on %s",
+			rate.Base, rate.Currency, rate.Date.Format("2006-01-02"), rate.Rate, change*100, previous.Rate, previous.Date.Format("2006-01-02"))
+	}
+
+	return true, nil
+}
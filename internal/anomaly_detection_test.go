@@ -0,0 +1,224 @@
+package internal_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fedorov-dmitry/go-test-api/internal"
+	"github.com/fedorov-dmitry/go-test-api/internal/memory"
+	"github.com/fedorov-dmitry/go-test-api/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCurrencySynchronizer_AnomalyDetection_QuarantinesLargeMoves(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockSource := mocks.NewMockCurrencyRateSource(ctrl)
+	mockStorage := mocks.NewMockCurrencyStorage(ctrl)
+	mockQuarantine := mocks.NewMockQuarantineStorage(ctrl)
+	repo := internal.NewCurrencyRepository(mockStorage)
+	quarantine := internal.NewQuarantineRepository(mockQuarantine, repo)
+
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+	jpy := internal.NewCurrency("jpy")
+	s := internal.NewCurrencySynchronizer(*repo, mockSource, []internal.Currency{usd, eur, jpy},
+		internal.WithAnomalyDetection(internal.AnomalyPolicy{FlagChange: 0.05, QuarantineChange: 0.5, LookbackDays: 7}, quarantine))
+
+	date := time.Date(2025, 1, 14, 0, 0, 0, 0, time.UTC)
+	previousDate := date.AddDate(0, 0, -1)
+
+	mockSource.
+		EXPECT().
+		Get(gomock.Any(), usd, []internal.Currency{eur, jpy}, date).
+		Return([]internal.CurrencyRate{
+			{Currency: eur, Rate: dec("0.99")},
+			{Currency: jpy, Rate: dec("145100")},
+		}, nil)
+
+	mockStorage.
+		EXPECT().
+		GetLatestMany(ctx, usd, date.AddDate(0, 0, -7), previousDate).
+		Return([]internal.CurrencyRate{
+			{Date: previousDate, Base: usd, Currency: eur, Rate: dec("0.92")},
+			{Date: previousDate, Base: usd, Currency: jpy, Rate: dec("145.1")},
+		}, nil)
+	mockStorage.
+		EXPECT().
+		GetMany(ctx, usd, date).
+		Return([]internal.CurrencyRate{}, nil)
+
+	mockStorage.
+		EXPECT().
+		SetMany(ctx, []internal.CurrencyRate{{Date: date, Base: usd, Currency: eur, Rate: dec("0.99")}}).
+		Return(nil)
+	mockQuarantine.
+		EXPECT().
+		GetRejected(ctx, internal.CurrencyRate{Date: date, Base: usd, Currency: jpy, Rate: dec("145100")}).
+		Return(internal.QuarantinedRate{}, internal.ErrQuarantinedRateNotFound)
+	mockQuarantine.
+		EXPECT().
+		Create(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, rate internal.QuarantinedRate) (int64, error) {
+			if !rate.Rate.Equal(internal.CurrencyRate{Date: date, Base: usd, Currency: jpy, Rate: dec("145100")}) {
+				t.Fatalf("unexpected quarantined rate: %+v", rate.Rate)
+			}
+			if rate.Status != internal.QuarantineStatusPending || !rate.PreviousRate.Equal(dec("145.1")) || !rate.PreviousDate.Equal(previousDate) {
+				t.Fatalf("unexpected quarantine entry: %+v", rate)
+			}
+			return 7, nil
+		})
+
+	report := s.Run(ctx, internal.SyncTriggerManual, []internal.SyncUnit{{Base: usd, Date: date}})
+	if err := report.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.RowsWritten() != 1 {
+		t.Fatalf("RowsWritten=%d, want 1", report.RowsWritten())
+	}
+}
+
+func TestCurrencySynchronizer_AnomalyDetection_IgnoresOldHistory(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockSource := mocks.NewMockCurrencyRateSource(ctrl)
+	mockStorage := mocks.NewMockCurrencyStorage(ctrl)
+	mockQuarantine := mocks.NewMockQuarantineStorage(ctrl)
+	repo := internal.NewCurrencyRepository(mockStorage)
+	quarantine := internal.NewQuarantineRepository(mockQuarantine, repo)
+
+	usd := internal.NewCurrency("usd")
+	rub := internal.NewCurrency("rub")
+	s := internal.NewCurrencySynchronizer(*repo, mockSource, []internal.Currency{usd, rub},
+		internal.WithAnomalyDetection(internal.AnomalyPolicy{QuarantineChange: 0.5, LookbackDays: 7}, quarantine))
+
+	date := time.Date(2025, 1, 14, 0, 0, 0, 0, time.UTC)
+
+	mockSource.
+		EXPECT().
+		Get(gomock.Any(), usd, []internal.Currency{rub}, date).
+		Return([]internal.CurrencyRate{{Currency: rub, Rate: dec("100")}}, nil)
+	mockStorage.
+		EXPECT().
+		GetLatestMany(ctx, usd, date.AddDate(0, 0, -7), date.AddDate(0, 0, -1)).
+		Return([]internal.CurrencyRate{}, nil)
+	mockStorage.
+		EXPECT().
+		SetMany(ctx, []internal.CurrencyRate{{Date: date, Base: usd, Currency: rub, Rate: dec("100")}}).
+		Return(nil)
+
+	if err := s.Run(ctx, internal.SyncTriggerManual, []internal.SyncUnit{{Base: usd, Date: date}}).Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCurrencySynchronizer_AnomalyDetection_SkipsRejectedRates(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockSource := mocks.NewMockCurrencyRateSource(ctrl)
+	mockStorage := mocks.NewMockCurrencyStorage(ctrl)
+	mockQuarantine := mocks.NewMockQuarantineStorage(ctrl)
+	repo := internal.NewCurrencyRepository(mockStorage)
+	quarantine := internal.NewQuarantineRepository(mockQuarantine, repo)
+
+	usd := internal.NewCurrency("usd")
+	jpy := internal.NewCurrency("jpy")
+	s := internal.NewCurrencySynchronizer(*repo, mockSource, []internal.Currency{usd, jpy},
+		internal.WithAnomalyDetection(internal.AnomalyPolicy{QuarantineChange: 0.5, LookbackDays: 7}, quarantine))
+
+	date := time.Date(2025, 1, 14, 0, 0, 0, 0, time.UTC)
+	rate := internal.CurrencyRate{Date: date, Base: usd, Currency: jpy, Rate: dec("145100")}
+
+	mockSource.
+		EXPECT().
+		Get(gomock.Any(), usd, []internal.Currency{jpy}, date).
+		Return([]internal.CurrencyRate{{Currency: jpy, Rate: dec("145100")}}, nil)
+	mockStorage.
+		EXPECT().
+		GetLatestMany(ctx, usd, date.AddDate(0, 0, -7), date.AddDate(0, 0, -1)).
+		Return([]internal.CurrencyRate{{Date: date.AddDate(0, 0, -1), Base: usd, Currency: jpy, Rate: dec("145.1")}}, nil)
+	mockStorage.
+		EXPECT().
+		GetMany(ctx, usd, date).
+		Return([]internal.CurrencyRate{}, nil)
+	mockQuarantine.
+		EXPECT().
+		GetRejected(ctx, rate).
+		Return(internal.QuarantinedRate{ID: 7, Rate: rate, Status: internal.QuarantineStatusRejected}, nil)
+
+	report := s.Run(ctx, internal.SyncTriggerManual, []internal.SyncUnit{{Base: usd, Date: date}})
+	if err := report.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.RowsWritten() != 0 {
+		t.Fatalf("RowsWritten=%d, want 0", report.RowsWritten())
+	}
+}
+
+func TestCurrencySynchronizer_AnomalyDetection_KeepsApprovedRatesOnResync(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockSource := mocks.NewMockCurrencyRateSource(ctrl)
+	rates := memory.NewCurrencyStorage()
+	repo := internal.NewCurrencyRepository(rates)
+	quarantine := internal.NewQuarantineRepository(memory.NewQuarantineStorage(rates), repo)
+
+	usd := internal.NewCurrency("usd")
+	jpy := internal.NewCurrency("jpy")
+	s := internal.NewCurrencySynchronizer(*repo, mockSource, []internal.Currency{usd, jpy},
+		internal.WithAnomalyDetection(internal.AnomalyPolicy{QuarantineChange: 0.5, LookbackDays: 7}, quarantine))
+
+	date := time.Date(2025, 1, 14, 0, 0, 0, 0, time.UTC)
+
+	if err := repo.Save(ctx, internal.CurrencyRate{Date: date.AddDate(0, 0, -1), Base: usd, Currency: jpy, Rate: dec("1")}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mockSource.
+		EXPECT().
+		Get(gomock.Any(), usd, []internal.Currency{jpy}, date).
+		Return([]internal.CurrencyRate{{Currency: jpy, Rate: dec("3")}}, nil).
+		Times(2)
+
+	if err := s.Run(ctx, internal.SyncTriggerManual, []internal.SyncUnit{{Base: usd, Date: date}}).Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pending, err := quarantine.List(ctx, internal.QuarantineStatusPending, 10)
+	if err != nil || len(pending) != 1 {
+		t.Fatalf("got %+v, %v, want one pending rate", pending, err)
+	}
+	if _, err := quarantine.Approve(ctx, pending[0].ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	report := s.Run(ctx, internal.SyncTriggerManual, []internal.SyncUnit{{Base: usd, Date: date}})
+	if err := report.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.RowsWritten() != 1 {
+		t.Fatalf("RowsWritten=%d, want 1", report.RowsWritten())
+	}
+
+	pending, err = quarantine.List(ctx, internal.QuarantineStatusPending, 10)
+	if err != nil || len(pending) != 0 {
+		t.Fatalf("got %+v, %v, want no pending rates", pending, err)
+	}
+}
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/fedorov-dmitry/go-test-api/internal/api (interfaces: CurrencyRepository)
+// Source: github.com/fedorov-dmitry/go-test-api/internal/api (interfaces: CurrencyRepository,SyncRunRepository,QuarantineRepository,CurrencyCatalog,TrackedCurrencyRepository,ApiKeyRepository)
 //
 // Generated by this command:
 //
-//	mockgen -package=apimocks -destination=/Users/dmitriy/Documents/Repo/go-test-api/internal/api/mocks/mock_api.go github.com/fedorov-dmitry/go-test-api/internal/api CurrencyRepository
+//	mockgen -package=apimocks -destination=/Users/dmitriy/Documents/Repo/go-test-api/internal/api/mocks/mock_api.go github.com/fedorov-dmitry/go-test-api/internal/api CurrencyRepository,SyncRunRepository,QuarantineRepository,CurrencyCatalog,TrackedCurrencyRepository,ApiKeyRepository
 //
 
 // Package apimocks is a generated GoMock package.
@@ -15,6 +15,7 @@ import (
 	time "time"
 
 	internal "github.com/fedorov-dmitry/go-test-api/internal"
+	decimal "github.com/shopspring/decimal"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -43,7 +44,7 @@ func (m *MockCurrencyRepository) EXPECT() *MockCurrencyRepositoryMockRecorder {
 }
 
 // Create mocks base method.
-func (m *MockCurrencyRepository) Create(ctx context.Context, date time.Time, baseCurrency, currency internal.Currency, rate float64) (internal.CurrencyRate, error) {
+func (m *MockCurrencyRepository) Create(ctx context.Context, date time.Time, baseCurrency, currency internal.Currency, rate decimal.Decimal) (internal.CurrencyRate, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Create", ctx, date, baseCurrency, currency, rate)
 	ret0, _ := ret[0].(internal.CurrencyRate)
@@ -72,6 +73,21 @@ func (mr *MockCurrencyRepositoryMockRecorder) Get(ctx, baseCurrency, currency, d
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCurrencyRepository)(nil).Get), ctx, baseCurrency, currency, date)
 }
 
+// GetLatest mocks base method.
+func (m *MockCurrencyRepository) GetLatest(ctx context.Context, baseCurrency, currency internal.Currency, date time.Time, maxStalenessDays int) (internal.CurrencyRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatest", ctx, baseCurrency, currency, date, maxStalenessDays)
+	ret0, _ := ret[0].(internal.CurrencyRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatest indicates an expected call of GetLatest.
+func (mr *MockCurrencyRepositoryMockRecorder) GetLatest(ctx, baseCurrency, currency, date, maxStalenessDays any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatest", reflect.TypeOf((*MockCurrencyRepository)(nil).GetLatest), ctx, baseCurrency, currency, date, maxStalenessDays)
+}
+
 // GetMany mocks base method.
 func (m *MockCurrencyRepository) GetMany(ctx context.Context, baseCurrency internal.Currency, date time.Time) ([]internal.CurrencyRate, error) {
 	m.ctrl.T.Helper()
@@ -86,3 +102,378 @@ func (mr *MockCurrencyRepositoryMockRecorder) GetMany(ctx, baseCurrency, date an
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMany", reflect.TypeOf((*MockCurrencyRepository)(nil).GetMany), ctx, baseCurrency, date)
 }
+
+// GetRange mocks base method.
+func (m *MockCurrencyRepository) GetRange(ctx context.Context, baseCurrency, currency internal.Currency, from, to time.Time) ([]internal.CurrencyRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRange", ctx, baseCurrency, currency, from, to)
+	ret0, _ := ret[0].([]internal.CurrencyRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRange indicates an expected call of GetRange.
+func (mr *MockCurrencyRepositoryMockRecorder) GetRange(ctx, baseCurrency, currency, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRange", reflect.TypeOf((*MockCurrencyRepository)(nil).GetRange), ctx, baseCurrency, currency, from, to)
+}
+
+// GetRevisions mocks base method.
+func (m *MockCurrencyRepository) GetRevisions(ctx context.Context, baseCurrency, currency internal.Currency, date time.Time) ([]internal.CurrencyRateRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevisions", ctx, baseCurrency, currency, date)
+	ret0, _ := ret[0].([]internal.CurrencyRateRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevisions indicates an expected call of GetRevisions.
+func (mr *MockCurrencyRepositoryMockRecorder) GetRevisions(ctx, baseCurrency, currency, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevisions", reflect.TypeOf((*MockCurrencyRepository)(nil).GetRevisions), ctx, baseCurrency, currency, date)
+}
+
+// MockSyncRunRepository is a mock of SyncRunRepository interface.
+type MockSyncRunRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSyncRunRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSyncRunRepositoryMockRecorder is the mock recorder for MockSyncRunRepository.
+type MockSyncRunRepositoryMockRecorder struct {
+	mock *MockSyncRunRepository
+}
+
+// NewMockSyncRunRepository creates a new mock instance.
+func NewMockSyncRunRepository(ctrl *gomock.Controller) *MockSyncRunRepository {
+	mock := &MockSyncRunRepository{ctrl: ctrl}
+	mock.recorder = &MockSyncRunRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSyncRunRepository) EXPECT() *MockSyncRunRepositoryMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockSyncRunRepository) List(ctx context.Context, limit int) ([]internal.SyncRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, limit)
+	ret0, _ := ret[0].([]internal.SyncRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockSyncRunRepositoryMockRecorder) List(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockSyncRunRepository)(nil).List), ctx, limit)
+}
+
+// Status mocks base method.
+func (m *MockSyncRunRepository) Status(ctx context.Context) (internal.SyncStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status", ctx)
+	ret0, _ := ret[0].(internal.SyncStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockSyncRunRepositoryMockRecorder) Status(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockSyncRunRepository)(nil).Status), ctx)
+}
+
+// MockQuarantineRepository is a mock of QuarantineRepository interface.
+type MockQuarantineRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuarantineRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockQuarantineRepositoryMockRecorder is the mock recorder for MockQuarantineRepository.
+type MockQuarantineRepositoryMockRecorder struct {
+	mock *MockQuarantineRepository
+}
+
+// NewMockQuarantineRepository creates a new mock instance.
+func NewMockQuarantineRepository(ctrl *gomock.Controller) *MockQuarantineRepository {
+	mock := &MockQuarantineRepository{ctrl: ctrl}
+	mock.recorder = &MockQuarantineRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQuarantineRepository) EXPECT() *MockQuarantineRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Approve mocks base method.
+func (m *MockQuarantineRepository) Approve(ctx context.Context, id int64) (internal.QuarantinedRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Approve", ctx, id)
+	ret0, _ := ret[0].(internal.QuarantinedRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Approve indicates an expected call of Approve.
+func (mr *MockQuarantineRepositoryMockRecorder) Approve(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockQuarantineRepository)(nil).Approve), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockQuarantineRepository) List(ctx context.Context, status internal.QuarantineStatus, limit int) ([]internal.QuarantinedRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, status, limit)
+	ret0, _ := ret[0].([]internal.QuarantinedRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockQuarantineRepositoryMockRecorder) List(ctx, status, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockQuarantineRepository)(nil).List), ctx, status, limit)
+}
+
+// Reject mocks base method.
+func (m *MockQuarantineRepository) Reject(ctx context.Context, id int64) (internal.QuarantinedRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reject", ctx, id)
+	ret0, _ := ret[0].(internal.QuarantinedRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reject indicates an expected call of Reject.
+func (mr *MockQuarantineRepositoryMockRecorder) Reject(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reject", reflect.TypeOf((*MockQuarantineRepository)(nil).Reject), ctx, id)
+}
+
+// MockCurrencyCatalog is a mock of CurrencyCatalog interface.
+type MockCurrencyCatalog struct {
+	ctrl     *gomock.Controller
+	recorder *MockCurrencyCatalogMockRecorder
+	isgomock struct{}
+}
+
+// MockCurrencyCatalogMockRecorder is the mock recorder for MockCurrencyCatalog.
+type MockCurrencyCatalogMockRecorder struct {
+	mock *MockCurrencyCatalog
+}
+
+// NewMockCurrencyCatalog creates a new mock instance.
+func NewMockCurrencyCatalog(ctrl *gomock.Controller) *MockCurrencyCatalog {
+	mock := &MockCurrencyCatalog{ctrl: ctrl}
+	mock.recorder = &MockCurrencyCatalogMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCurrencyCatalog) EXPECT() *MockCurrencyCatalogMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockCurrencyCatalog) Get(currency internal.Currency) (internal.CurrencyInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", currency)
+	ret0, _ := ret[0].(internal.CurrencyInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCurrencyCatalogMockRecorder) Get(currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCurrencyCatalog)(nil).Get), currency)
+}
+
+// List mocks base method.
+func (m *MockCurrencyCatalog) List() []internal.CurrencyInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]internal.CurrencyInfo)
+	return ret0
+}
+
+// List indicates an expected call of List.
+func (mr *MockCurrencyCatalogMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCurrencyCatalog)(nil).List))
+}
+
+// MockTrackedCurrencyRepository is a mock of TrackedCurrencyRepository interface.
+type MockTrackedCurrencyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrackedCurrencyRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTrackedCurrencyRepositoryMockRecorder is the mock recorder for MockTrackedCurrencyRepository.
+type MockTrackedCurrencyRepositoryMockRecorder struct {
+	mock *MockTrackedCurrencyRepository
+}
+
+// NewMockTrackedCurrencyRepository creates a new mock instance.
+func NewMockTrackedCurrencyRepository(ctrl *gomock.Controller) *MockTrackedCurrencyRepository {
+	mock := &MockTrackedCurrencyRepository{ctrl: ctrl}
+	mock.recorder = &MockTrackedCurrencyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTrackedCurrencyRepository) EXPECT() *MockTrackedCurrencyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockTrackedCurrencyRepository) Add(ctx context.Context, currency internal.Currency) (internal.TrackedCurrency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, currency)
+	ret0, _ := ret[0].(internal.TrackedCurrency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockTrackedCurrencyRepositoryMockRecorder) Add(ctx, currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockTrackedCurrencyRepository)(nil).Add), ctx, currency)
+}
+
+// List mocks base method.
+func (m *MockTrackedCurrencyRepository) List(ctx context.Context) ([]internal.TrackedCurrency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]internal.TrackedCurrency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockTrackedCurrencyRepositoryMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTrackedCurrencyRepository)(nil).List), ctx)
+}
+
+// Remove mocks base method.
+func (m *MockTrackedCurrencyRepository) Remove(ctx context.Context, currency internal.Currency) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", ctx, currency)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockTrackedCurrencyRepositoryMockRecorder) Remove(ctx, currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockTrackedCurrencyRepository)(nil).Remove), ctx, currency)
+}
+
+// MockApiKeyRepository is a mock of ApiKeyRepository interface.
+type MockApiKeyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockApiKeyRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockApiKeyRepositoryMockRecorder is the mock recorder for MockApiKeyRepository.
+type MockApiKeyRepositoryMockRecorder struct {
+	mock *MockApiKeyRepository
+}
+
+// NewMockApiKeyRepository creates a new mock instance.
+func NewMockApiKeyRepository(ctrl *gomock.Controller) *MockApiKeyRepository {
+	mock := &MockApiKeyRepository{ctrl: ctrl}
+	mock.recorder = &MockApiKeyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApiKeyRepository) EXPECT() *MockApiKeyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockApiKeyRepository) Authenticate(ctx context.Context, secret string, scope internal.ApiKeyScope) (internal.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", ctx, secret, scope)
+	ret0, _ := ret[0].(internal.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockApiKeyRepositoryMockRecorder) Authenticate(ctx, secret, scope any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockApiKeyRepository)(nil).Authenticate), ctx, secret, scope)
+}
+
+// Create mocks base method.
+func (m *MockApiKeyRepository) Create(ctx context.Context, name string, scopes []internal.ApiKeyScope, expiresAt *time.Time) (internal.ApiKey, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, name, scopes, expiresAt)
+	ret0, _ := ret[0].(internal.ApiKey)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockApiKeyRepositoryMockRecorder) Create(ctx, name, scopes, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockApiKeyRepository)(nil).Create), ctx, name, scopes, expiresAt)
+}
+
+// List mocks base method.
+func (m *MockApiKeyRepository) List(ctx context.Context) ([]internal.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]internal.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockApiKeyRepositoryMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockApiKeyRepository)(nil).List), ctx)
+}
+
+// Revoke mocks base method.
+func (m *MockApiKeyRepository) Revoke(ctx context.Context, id int64) (internal.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, id)
+	ret0, _ := ret[0].(internal.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockApiKeyRepositoryMockRecorder) Revoke(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockApiKeyRepository)(nil).Revoke), ctx, id)
+}
+
+// Rotate mocks base method.
+func (m *MockApiKeyRepository) Rotate(ctx context.Context, id int64) (internal.ApiKey, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rotate", ctx, id)
+	ret0, _ := ret[0].(internal.ApiKey)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Rotate indicates an expected call of Rotate.
+func (mr *MockApiKeyRepositoryMockRecorder) Rotate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rotate", reflect.TypeOf((*MockApiKeyRepository)(nil).Rotate), ctx, id)
+}
@@ -2,32 +2,153 @@ package api
 
 import (
 	"context"
+	"crypto/subtle"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/fedorov-dmitry/go-test-api/internal"
 	"github.com/fedorov-dmitry/go-test-api/internal/middleware"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	defaultMaxStalenessDays = 3
+	defaultSyncRunsLimit    = 20
+	maxSyncRunsLimit        = 500
+	maxBackfillDays         = 3660
+	defaultQuarantineLimit  = 50
+	maxQuarantineLimit      = 500
+	maxAmountDigits         = 40
+	maxAmountExponent       = 20
 )
 
 type CurrencyRepository interface {
 	Get(ctx context.Context, baseCurrency internal.Currency, currency internal.Currency, date time.Time) (internal.CurrencyRate, error)
 	GetMany(ctx context.Context, baseCurrency internal.Currency, date time.Time) ([]internal.CurrencyRate, error)
-	Create(ctx context.Context, date time.Time, baseCurrency internal.Currency, currency internal.Currency, rate float64) (internal.CurrencyRate, error)
+	GetRange(ctx context.Context, baseCurrency internal.Currency, currency internal.Currency, from time.Time, to time.Time) ([]internal.CurrencyRate, error)
+	GetLatest(ctx context.Context, baseCurrency internal.Currency, currency internal.Currency, date time.Time, maxStalenessDays int) (internal.CurrencyRate, error)
+	GetRevisions(ctx context.Context, baseCurrency internal.Currency, currency internal.Currency, date time.Time) ([]internal.CurrencyRateRevision, error)
+	Create(ctx context.Context, date time.Time, baseCurrency internal.Currency, currency internal.Currency, rate decimal.Decimal) (internal.CurrencyRate, error)
+}
+
+type SyncRunRepository interface {
+	List(ctx context.Context, limit int) ([]internal.SyncRun, error)
+	Status(ctx context.Context) (internal.SyncStatus, error)
+}
+
+type QuarantineRepository interface {
+	List(ctx context.Context, status internal.QuarantineStatus, limit int) ([]internal.QuarantinedRate, error)
+	Approve(ctx context.Context, id int64) (internal.QuarantinedRate, error)
+	Reject(ctx context.Context, id int64) (internal.QuarantinedRate, error)
+}
+
+type RateCache interface {
+	Stats() internal.CacheStats
+}
+
+type CurrencyCatalog interface {
+	List() []internal.CurrencyInfo
+	Get(currency internal.Currency) (internal.CurrencyInfo, error)
+}
+
+type TrackedCurrencyRepository interface {
+	List(ctx context.Context) ([]internal.TrackedCurrency, error)
+	Add(ctx context.Context, currency internal.Currency) (internal.TrackedCurrency, error)
+	Remove(ctx context.Context, currency internal.Currency) error
+}
+
+type ApiKeyRepository interface {
+	List(ctx context.Context) ([]internal.ApiKey, error)
+	Create(ctx context.Context, name string, scopes []internal.ApiKeyScope, expiresAt *time.Time) (internal.ApiKey, string, error)
+	Rotate(ctx context.Context, id int64) (internal.ApiKey, string, error)
+	Revoke(ctx context.Context, id int64) (internal.ApiKey, error)
+	Authenticate(ctx context.Context, secret string, scope internal.ApiKeyScope) (internal.ApiKey, error)
 }
 
 type Server struct {
-	repo        CurrencyRepository
-	service     internal.CurrencySynchronizer
-	mainContext context.Context
-	logCh       chan<- middleware.RequestLog
-	port        int
-	apiKey      string
+	repo             CurrencyRepository
+	service          internal.CurrencySynchronizer
+	mainContext      context.Context
+	logCh            chan<- middleware.RequestLog
+	port             int
+	apiKey           string
+	maxStalenessDays int
+	syncRuns         SyncRunRepository
+	adminApiKey      string
+	jobs             *internal.SyncJobManager
+	quarantine       QuarantineRepository
+	cache            RateCache
+	currencies       CurrencyCatalog
+	tracked          TrackedCurrencyRepository
+	apiKeys          ApiKeyRepository
+}
+
+type ServerOption func(*Server)
+
+func WithMaxStalenessDays(days int) ServerOption {
+	return func(s *Server) {
+		s.maxStalenessDays = days
+	}
+}
+
+func WithSyncRuns(syncRuns SyncRunRepository) ServerOption {
+	return func(s *Server) {
+		s.syncRuns = syncRuns
+	}
+}
+
+func WithAdminApiKey(adminApiKey string) ServerOption {
+	return func(s *Server) {
+		s.adminApiKey = adminApiKey
+	}
+}
+
+func WithQuarantine(quarantine QuarantineRepository) ServerOption {
+	return func(s *Server) {
+		s.quarantine = quarantine
+	}
 }
 
-func NewServer(repo CurrencyRepository, service internal.CurrencySynchronizer, mainContext context.Context, logCh chan<- middleware.RequestLog, port int, apiKey string) *Server {
-	return &Server{repo: repo, service: service, mainContext: mainContext, logCh: logCh, port: port, apiKey: apiKey}
+func WithCache(cache RateCache) ServerOption {
+	return func(s *Server) {
+		s.cache = cache
+	}
+}
+
+func WithCurrencyCatalog(currencies CurrencyCatalog) ServerOption {
+	return func(s *Server) {
+		s.currencies = currencies
+	}
+}
+
+func WithTrackedCurrencies(tracked TrackedCurrencyRepository) ServerOption {
+	return func(s *Server) {
+		s.tracked = tracked
+	}
+}
+
+func WithApiKeys(apiKeys ApiKeyRepository) ServerOption {
+	return func(s *Server) {
+		s.apiKeys = apiKeys
+	}
+}
+
+func NewServer(repo CurrencyRepository, service internal.CurrencySynchronizer, mainContext context.Context, logCh chan<- middleware.RequestLog, port int, apiKey string, opts ...ServerOption) *Server {
+	s := &Server{repo: repo, service: service, mainContext: mainContext, logCh: logCh, port: port, apiKey: apiKey, maxStalenessDays: defaultMaxStalenessDays}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.jobs = internal.NewSyncJobManager(&s.service)
+
+	return s
 }
 
 func (s *Server) Start() error {
@@ -45,44 +166,152 @@ func (s *Server) getHandlers() *http.ServeMux {
 	wrap := func(h http.HandlerFunc) http.Handler {
 		return middleware.RequestLoggingMiddleware(
 			s.logCh,
-			middleware.AuthorizationMiddleware(s.apiKey, h),
+			s.authorization(internal.ApiKeyScopeReadRates, h),
 		)
 	}
 
 	mux.Handle("/rates/historical", wrap(s.historicalRatesHandler))
 	mux.Handle("/rates/latest", wrap(s.currentRatesHandler))
+	mux.Handle("/rates/timeseries", wrap(s.timeseriesRatesHandler))
+	mux.Handle("/rates/revisions", wrap(s.revisionsHandler))
+	mux.Handle("/convert", wrap(s.convertHandler))
+
+	if s.currencies != nil {
+		mux.Handle("/currencies", wrap(s.currenciesHandler))
+	}
+
+	if s.syncRuns != nil {
+		mux.Handle("/sync/runs", wrap(s.syncRunsHandler))
+		mux.Handle("/sync/status", wrap(s.syncStatusHandler))
+	}
+
+	if s.adminApiKey != "" || s.apiKeys != nil {
+		trigger := func(h http.HandlerFunc) http.Handler {
+			return middleware.RequestLoggingMiddleware(
+				s.logCh,
+				s.authorization(internal.ApiKeyScopeSync, h),
+			)
+		}
+
+		admin := func(h http.HandlerFunc) http.Handler {
+			return middleware.RequestLoggingMiddleware(
+				s.logCh,
+				s.authorization(internal.ApiKeyScopeAdmin, h),
+			)
+		}
+
+		mux.Handle("POST /admin/sync", trigger(s.adminSyncHandler))
+		mux.Handle("POST /admin/backfill", trigger(s.adminBackfillHandler))
+		mux.Handle("GET /admin/jobs", trigger(s.adminJobsHandler))
+		mux.Handle("GET /admin/jobs/{id}", trigger(s.adminJobHandler))
+		mux.Handle("GET /admin/gaps", trigger(s.adminGapsHandler))
+		mux.Handle("POST /admin/gaps/fill", trigger(s.adminFillGapsHandler))
+
+		if s.quarantine != nil {
+			mux.Handle("GET /admin/quarantine", admin(s.adminQuarantineHandler))
+			mux.Handle("POST /admin/quarantine/{id}/approve", admin(s.adminApproveQuarantinedHandler))
+			mux.Handle("POST /admin/quarantine/{id}/reject", admin(s.adminRejectQuarantinedHandler))
+		}
+
+		if s.cache != nil {
+			mux.Handle("GET /admin/cache", admin(s.adminCacheHandler))
+		}
+
+		if s.tracked != nil {
+			mux.Handle("GET /admin/currencies", admin(s.adminTrackedCurrenciesHandler))
+			mux.Handle("POST /admin/currencies", admin(s.adminTrackCurrencyHandler))
+			mux.Handle("DELETE /admin/currencies/{currency}", admin(s.adminUntrackCurrencyHandler))
+		}
+
+		if s.apiKeys != nil {
+			mux.Handle("GET /admin/keys", admin(s.adminApiKeysHandler))
+			mux.Handle("POST /admin/keys", admin(s.adminCreateApiKeyHandler))
+			mux.Handle("POST /admin/keys/{id}/rotate", admin(s.adminRotateApiKeyHandler))
+			mux.Handle("POST /admin/keys/{id}/revoke", admin(s.adminRevokeApiKeyHandler))
+		}
+	}
 
 	return mux
 }
 
+func (s *Server) authorization(scope internal.ApiKeyScope, next http.Handler) http.Handler {
+	if s.apiKeys == nil {
+		if scope == internal.ApiKeyScopeReadRates {
+			return middleware.AuthorizationMiddleware(s.apiKey, next)
+		}
+
+		return middleware.AuthorizationMiddleware(s.adminApiKey, next)
+	}
+
+	return middleware.KeyAuthorizationMiddleware(func(ctx context.Context, apiKey string) error {
+		return s.authorize(ctx, apiKey, scope)
+	}, next)
+}
+
+func (s *Server) authorize(ctx context.Context, apiKey string, scope internal.ApiKeyScope) error {
+	switch {
+	case apiKey == "":
+		return middleware.ErrUnauthorized
+	case s.adminApiKey != "" && subtle.ConstantTimeCompare([]byte(apiKey), []byte(s.adminApiKey)) == 1:
+		return nil
+	case s.apiKey != "" && subtle.ConstantTimeCompare([]byte(apiKey), []byte(s.apiKey)) == 1:
+		if scope != internal.ApiKeyScopeReadRates {
+			return middleware.ErrForbidden
+		}
+		return nil
+	}
+
+	_, err := s.apiKeys.Authenticate(ctx, apiKey, scope)
+	switch {
+	case errors.Is(err, internal.ErrApiKeyMissingScope):
+		return fmt.Errorf("%w: %w", middleware.ErrForbidden, err)
+	case errors.Is(err, internal.ErrApiKeyNotFound), errors.Is(err, internal.ErrApiKeyRevoked), errors.Is(err, internal.ErrApiKeyExpired):
+		return fmt.Errorf("%w: %w", middleware.ErrUnauthorized, err)
+	}
+
+	return err
+}
+
 func (s *Server) currentRatesHandler(w http.ResponseWriter, r *http.Request) {
-	base := internal.NewCurrency(r.URL.Query().Get("base"))
-	if base == "" {
-		http.Error(w, "missing `base` query parameter", http.StatusBadRequest)
+	base, ok := s.currencyParam(w, r, "base")
+	if !ok {
 		return
 	}
 
-	currency := internal.NewCurrency(r.URL.Query().Get("currency"))
-	if currency == "" {
-		http.Error(w, "missing `currency` query parameter", http.StatusBadRequest)
+	currency, ok := s.currencyParam(w, r, "currency")
+	if !ok {
 		return
 	}
 
-	rates, err := s.repo.Get(s.mainContext, base, currency, time.Now())
+	if !s.activeCurrency(w, "base", base) || !s.activeCurrency(w, "currency", currency) {
+		return
+	}
+
+	ctx, now, err := s.queryContext(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rate, err := s.repo.GetLatest(ctx, base, currency, now, s.maxStalenessDays)
+	if err != nil {
+		writeRepositoryError(w, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 
-	_ = json.NewEncoder(w).Encode(rates) // handle?
+	_ = json.NewEncoder(w).Encode(latestRateResponse{CurrencyRate: rate, AsOf: rate.Date.Format("2006-01-02")}) // handle?
+}
+
+type latestRateResponse struct {
+	internal.CurrencyRate
+	AsOf string
 }
 
 func (s *Server) historicalRatesHandler(w http.ResponseWriter, r *http.Request) {
-	base := internal.NewCurrency(r.URL.Query().Get("base"))
-	if base == "" {
-		http.Error(w, "missing base query parameter", http.StatusBadRequest)
+	base, ok := s.currencyParam(w, r, "base")
+	if !ok {
 		return
 	}
 
@@ -98,7 +327,13 @@ func (s *Server) historicalRatesHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	rates, err := s.repo.GetMany(s.mainContext, internal.Currency(base), date)
+	ctx, _, err := s.queryContext(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rates, err := s.repo.GetMany(ctx, internal.Currency(base), date)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -108,3 +343,681 @@ func (s *Server) historicalRatesHandler(w http.ResponseWriter, r *http.Request)
 
 	_ = json.NewEncoder(w).Encode(rates) // handle?
 }
+
+func (s *Server) timeseriesRatesHandler(w http.ResponseWriter, r *http.Request) {
+	base, ok := s.currencyParam(w, r, "base")
+	if !ok {
+		return
+	}
+
+	currency, ok := s.currencyParam(w, r, "currency")
+	if !ok {
+		return
+	}
+
+	from, err := time.Parse("2006-01-02", r.URL.Query().Get("from"))
+	if err != nil {
+		http.Error(w, "missing or invalid `from` query parameter, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+
+	to, err := time.Parse("2006-01-02", r.URL.Query().Get("to"))
+	if err != nil {
+		http.Error(w, "missing or invalid `to` query parameter, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+
+	if to.Before(from) {
+		http.Error(w, "`from` must not be after `to`", http.StatusBadRequest)
+		return
+	}
+
+	ctx, _, err := s.queryContext(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rates, err := s.repo.GetRange(ctx, base, currency, from, to)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_ = json.NewEncoder(w).Encode(rates) // handle?
+}
+
+func (s *Server) convertHandler(w http.ResponseWriter, r *http.Request) {
+	from, ok := s.currencyParam(w, r, "from")
+	if !ok {
+		return
+	}
+
+	to, ok := s.currencyParam(w, r, "to")
+	if !ok {
+		return
+	}
+
+	amount, err := parseAmount(r.URL.Query().Get("amount"))
+	if err != nil {
+		http.Error(w, "missing or invalid `amount` query parameter", http.StatusBadRequest)
+		return
+	}
+
+	ctx, now, err := s.queryContext(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var rate internal.CurrencyRate
+	dateStr := r.URL.Query().Get("date")
+	if dateStr == "" {
+		if !s.activeCurrency(w, "from", from) || !s.activeCurrency(w, "to", to) {
+			return
+		}
+
+		rate, err = s.repo.GetLatest(ctx, from, to, now, s.maxStalenessDays)
+	} else {
+		var date time.Time
+		date, err = time.Parse("2006-01-02", dateStr)
+		if err != nil {
+			http.Error(w, "invalid date format, expected YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+
+		rate, err = s.repo.Get(ctx, from, to, date)
+	}
+	if err != nil {
+		writeRepositoryError(w, err)
+		return
+	}
+
+	minorUnits := to.MinorUnits()
+	if s.currencies != nil {
+		info, err := s.currencies.Get(to)
+		if err == nil {
+			minorUnits = info.MinorUnits
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_ = json.NewEncoder(w).Encode(internal.NewConversion(rate, amount, minorUnits)) // handle?
+}
+
+func parseAmount(str string) (decimal.Decimal, error) {
+	if len(str) > maxAmountDigits+2 {
+		return decimal.Decimal{}, fmt.Errorf("amount %q is too long", str)
+	}
+
+	amount, err := decimal.NewFromString(str)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+
+	if amount.NumDigits() > maxAmountDigits || amount.Exponent() > maxAmountExponent || amount.Exponent() < -maxAmountExponent {
+		return decimal.Decimal{}, fmt.Errorf("amount %q is out of range", str)
+	}
+
+	return amount, nil
+}
+
+func (s *Server) currenciesHandler(w http.ResponseWriter, r *http.Request) {
+	kind := internal.CurrencyKind(r.URL.Query().Get("kind"))
+	switch kind {
+	case "", internal.CurrencyKindFiat, internal.CurrencyKindCrypto:
+	default:
+		http.Error(w, "invalid `kind` query parameter, expected fiat or crypto", http.StatusBadRequest)
+		return
+	}
+
+	includeInactive := false
+	if includeInactiveStr := r.URL.Query().Get("include_inactive"); includeInactiveStr != "" {
+		var err error
+		includeInactive, err = strconv.ParseBool(includeInactiveStr)
+		if err != nil {
+			http.Error(w, "invalid `include_inactive` query parameter, expected true or false", http.StatusBadRequest)
+			return
+		}
+	}
+
+	currencies := make([]internal.CurrencyInfo, 0)
+	for _, currency := range s.currencies.List() {
+		if (kind == "" || currency.Kind == kind) && (includeInactive || currency.Active) {
+			currencies = append(currencies, currency)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_ = json.NewEncoder(w).Encode(currencies) // handle?
+}
+
+func (s *Server) revisionsHandler(w http.ResponseWriter, r *http.Request) {
+	base, ok := s.currencyParam(w, r, "base")
+	if !ok {
+		return
+	}
+
+	currency, ok := s.currencyParam(w, r, "currency")
+	if !ok {
+		return
+	}
+
+	date, err := time.Parse("2006-01-02", r.URL.Query().Get("date"))
+	if err != nil {
+		http.Error(w, "missing or invalid `date` query parameter, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+
+	revisions, err := s.repo.GetRevisions(s.mainContext, base, currency, date)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_ = json.NewEncoder(w).Encode(revisions) // handle?
+}
+
+func (s *Server) currencyParam(w http.ResponseWriter, r *http.Request, name string) (internal.Currency, bool) {
+	currency := internal.NewCurrency(r.URL.Query().Get(name))
+	if currency == "" {
+		http.Error(w, fmt.Sprintf("missing `%s` query parameter", name), http.StatusBadRequest)
+		return "", false
+	}
+
+	if s.currencies != nil {
+		_, err := s.currencies.Get(currency)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid `%s` query parameter: %v", name, err), http.StatusBadRequest)
+			return "", false
+		}
+	}
+
+	return currency, true
+}
+
+func (s *Server) activeCurrency(w http.ResponseWriter, name string, currency internal.Currency) bool {
+	if s.currencies == nil {
+		return true
+	}
+
+	info, err := s.currencies.Get(currency)
+	if err == nil && !info.Active {
+		http.Error(w, fmt.Sprintf("invalid `%s` query parameter: %v: %q has no current rates, pass a `date` to query its history", name, internal.ErrInactiveCurrency, currency), http.StatusBadRequest)
+		return false
+	}
+
+	return true
+}
+
+func (s *Server) queryContext(r *http.Request) (context.Context, time.Time, error) {
+	asKnownAtStr := r.URL.Query().Get("as_known_at")
+	if asKnownAtStr == "" {
+		return s.mainContext, time.Now(), nil
+	}
+
+	asKnownAt, err := time.Parse(time.RFC3339, asKnownAtStr)
+	if err != nil {
+		return nil, time.Time{}, errors.New("invalid `as_known_at` query parameter, expected RFC 3339 timestamp, e.g. 2025-01-14T12:00:00Z")
+	}
+
+	return internal.WithAsKnownAt(s.mainContext, asKnownAt), asKnownAt, nil
+}
+
+func writeRepositoryError(w http.ResponseWriter, err error) {
+	if errors.Is(err, internal.ErrRateNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+
+func (s *Server) syncRunsHandler(w http.ResponseWriter, r *http.Request) {
+	limit := defaultSyncRunsLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > maxSyncRunsLimit {
+			http.Error(w, fmt.Sprintf("invalid `limit` query parameter, expected 1..%d", maxSyncRunsLimit), http.StatusBadRequest)
+			return
+		}
+	}
+
+	runs, err := s.syncRuns.List(s.mainContext, limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_ = json.NewEncoder(w).Encode(runs) // handle?
+}
+
+func (s *Server) syncStatusHandler(w http.ResponseWriter, r *http.Request) {
+	status, err := s.syncRuns.Status(s.mainContext)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_ = json.NewEncoder(w).Encode(status) // handle?
+}
+
+func (s *Server) adminCacheHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_ = json.NewEncoder(w).Encode(s.cache.Stats()) // handle?
+}
+
+func (s *Server) adminSyncHandler(w http.ResponseWriter, r *http.Request) {
+	days := 0
+	if daysStr := r.URL.Query().Get("days"); daysStr != "" {
+		var err error
+		days, err = strconv.Atoi(daysStr)
+		if err != nil || days < 0 || days > maxBackfillDays {
+			http.Error(w, fmt.Sprintf("invalid `days` query parameter, expected 0..%d", maxBackfillDays), http.StatusBadRequest)
+			return
+		}
+	}
+
+	units, err := s.service.UnitsForTodayAndLastNDays(r.Context(), days)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	s.startJob(w, internal.SyncTriggerManual, units)
+}
+
+func (s *Server) adminBackfillHandler(w http.ResponseWriter, r *http.Request) {
+	from, err := time.Parse("2006-01-02", r.URL.Query().Get("from"))
+	if err != nil {
+		http.Error(w, "missing or invalid `from` query parameter, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+
+	to, err := time.Parse("2006-01-02", r.URL.Query().Get("to"))
+	if err != nil {
+		http.Error(w, "missing or invalid `to` query parameter, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+
+	if to.Before(from) || to.Sub(from) > maxBackfillDays*24*time.Hour {
+		http.Error(w, fmt.Sprintf("`from` must not be after `to` and the range must not exceed %d days", maxBackfillDays), http.StatusBadRequest)
+		return
+	}
+
+	bases := make([]internal.Currency, 0)
+	for _, base := range strings.Split(r.URL.Query().Get("bases"), ",") {
+		if base = strings.TrimSpace(base); base != "" {
+			bases = append(bases, internal.NewCurrency(base))
+		}
+	}
+
+	if s.currencies != nil {
+		for _, base := range bases {
+			_, err := s.currencies.Get(base)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("invalid `bases` query parameter: %v", err), http.StatusBadRequest)
+				return
+			}
+		}
+	}
+
+	units, err := s.service.UnitsForRange(r.Context(), from, to, bases)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.startJob(w, internal.SyncTriggerBackfill, units)
+}
+
+func (s *Server) adminTrackedCurrenciesHandler(w http.ResponseWriter, r *http.Request) {
+	currencies, err := s.tracked.List(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_ = json.NewEncoder(w).Encode(currencies) // handle?
+}
+
+type trackedCurrencyResponse struct {
+	internal.TrackedCurrency
+	Job *internal.SyncJob `json:",omitempty"`
+}
+
+func (s *Server) adminTrackCurrencyHandler(w http.ResponseWriter, r *http.Request) {
+	currencyStr := r.URL.Query().Get("currency")
+	if currencyStr == "" {
+		http.Error(w, "missing `currency` query parameter", http.StatusBadRequest)
+		return
+	}
+	currency := internal.NewCurrency(currencyStr)
+
+	backfillDays := -1
+	if daysStr := r.URL.Query().Get("backfill_days"); daysStr != "" {
+		var err error
+		backfillDays, err = strconv.Atoi(daysStr)
+		if err != nil || backfillDays < 0 || backfillDays > maxBackfillDays {
+			http.Error(w, fmt.Sprintf("invalid `backfill_days` query parameter, expected 0..%d", maxBackfillDays), http.StatusBadRequest)
+			return
+		}
+	}
+
+	tracked, err := s.tracked.Add(r.Context(), currency)
+	switch {
+	case errors.Is(err, internal.ErrCurrencyAlreadyTracked):
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	case errors.Is(err, internal.ErrUnknownCurrency), errors.Is(err, internal.ErrInactiveCurrency):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := trackedCurrencyResponse{TrackedCurrency: tracked}
+
+	if backfillDays >= 0 {
+		units, err := s.service.UnitsForNewCurrency(r.Context(), currency, backfillDays)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		job, err := s.jobs.Start(s.mainContext, internal.SyncTriggerBackfill, units)
+		switch {
+		case errors.Is(err, internal.ErrSyncJobRunning):
+			log.Printf("skipped backfill of newly tracked currency %s: %v", currency, err)
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		default:
+			response.Job = &job
+			w.Header().Set("Location", "/admin/jobs/"+job.ID)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	_ = json.NewEncoder(w).Encode(response) // handle?
+}
+
+func (s *Server) adminUntrackCurrencyHandler(w http.ResponseWriter, r *http.Request) {
+	currency := internal.NewCurrency(r.PathValue("currency"))
+
+	err := s.tracked.Remove(r.Context(), currency)
+	switch {
+	case errors.Is(err, internal.ErrCurrencyNotTracked):
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) adminApiKeysHandler(w http.ResponseWriter, r *http.Request) {
+	keys, err := s.apiKeys.List(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_ = json.NewEncoder(w).Encode(keys) // handle?
+}
+
+type apiKeyResponse struct {
+	internal.ApiKey
+	Key string
+}
+
+func (s *Server) adminCreateApiKeyHandler(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimSpace(r.URL.Query().Get("name"))
+	if name == "" {
+		http.Error(w, "missing `name` query parameter", http.StatusBadRequest)
+		return
+	}
+
+	scopes := make([]internal.ApiKeyScope, 0)
+	for _, scopeStr := range strings.Split(r.URL.Query().Get("scopes"), ",") {
+		if scopeStr = strings.TrimSpace(scopeStr); scopeStr == "" {
+			continue
+		}
+
+		scope, err := internal.ParseApiKeyScope(scopeStr)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid `scopes` query parameter: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	if len(scopes) == 0 {
+		http.Error(w, "missing `scopes` query parameter", http.StatusBadRequest)
+		return
+	}
+
+	var expiresAt *time.Time
+	if expiresStr := r.URL.Query().Get("expires_at"); expiresStr != "" {
+		expires, err := time.Parse("2006-01-02", expiresStr)
+		if err != nil || !expires.After(time.Now()) {
+			http.Error(w, "invalid `expires_at` query parameter, expected a future YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+		expiresAt = &expires
+	}
+
+	key, secret, err := s.apiKeys.Create(r.Context(), name, scopes, expiresAt)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	_ = json.NewEncoder(w).Encode(apiKeyResponse{ApiKey: key, Key: secret}) // handle?
+}
+
+func (s *Server) adminRotateApiKeyHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid api key id", http.StatusBadRequest)
+		return
+	}
+
+	key, secret, err := s.apiKeys.Rotate(r.Context(), id)
+	if !s.checkApiKeyError(w, err) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_ = json.NewEncoder(w).Encode(apiKeyResponse{ApiKey: key, Key: secret}) // handle?
+}
+
+func (s *Server) adminRevokeApiKeyHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid api key id", http.StatusBadRequest)
+		return
+	}
+
+	key, err := s.apiKeys.Revoke(r.Context(), id)
+	if !s.checkApiKeyError(w, err) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_ = json.NewEncoder(w).Encode(key) // handle?
+}
+
+func (s *Server) checkApiKeyError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, internal.ErrApiKeyNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return false
+	case errors.Is(err, internal.ErrApiKeyRevoked):
+		http.Error(w, err.Error(), http.StatusConflict)
+		return false
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return false
+	}
+
+	return true
+}
+
+func (s *Server) startJob(w http.ResponseWriter, trigger internal.SyncTrigger, units []internal.SyncUnit) {
+	job, err := s.jobs.Start(s.mainContext, trigger, units)
+	switch {
+	case errors.Is(err, internal.ErrSyncJobRunning):
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", "/admin/jobs/"+job.ID)
+	w.WriteHeader(http.StatusAccepted)
+
+	_ = json.NewEncoder(w).Encode(job) // handle?
+}
+
+func (s *Server) adminJobsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_ = json.NewEncoder(w).Encode(s.jobs.List()) // handle?
+}
+
+func (s *Server) adminJobHandler(w http.ResponseWriter, r *http.Request) {
+	job, err := s.jobs.Get(r.PathValue("id"))
+	switch {
+	case errors.Is(err, internal.ErrSyncJobNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_ = json.NewEncoder(w).Encode(job) // handle?
+}
+
+func (s *Server) adminGapsHandler(w http.ResponseWriter, r *http.Request) {
+	report, err := s.service.DetectGaps(s.mainContext)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_ = json.NewEncoder(w).Encode(report) // handle?
+}
+
+func (s *Server) adminFillGapsHandler(w http.ResponseWriter, r *http.Request) {
+	report, err := s.service.DetectGaps(s.mainContext)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	s.startJob(w, internal.SyncTriggerGapFill, report.Units())
+}
+
+func (s *Server) adminQuarantineHandler(w http.ResponseWriter, r *http.Request) {
+	status := internal.QuarantineStatus(r.URL.Query().Get("status"))
+	switch status {
+	case "":
+		status = internal.QuarantineStatusPending
+	case "all":
+		status = ""
+	case internal.QuarantineStatusPending, internal.QuarantineStatusApproved, internal.QuarantineStatusRejected:
+	default:
+		http.Error(w, "invalid `status` query parameter, expected pending, approved, rejected or all", http.StatusBadRequest)
+		return
+	}
+
+	limit := defaultQuarantineLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > maxQuarantineLimit {
+			http.Error(w, fmt.Sprintf("invalid `limit` query parameter, expected 1..%d", maxQuarantineLimit), http.StatusBadRequest)
+			return
+		}
+	}
+
+	rates, err := s.quarantine.List(s.mainContext, status, limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_ = json.NewEncoder(w).Encode(rates) // handle?
+}
+
+func (s *Server) adminApproveQuarantinedHandler(w http.ResponseWriter, r *http.Request) {
+	s.resolveQuarantined(w, r, s.quarantine.Approve)
+}
+
+func (s *Server) adminRejectQuarantinedHandler(w http.ResponseWriter, r *http.Request) {
+	s.resolveQuarantined(w, r, s.quarantine.Reject)
+}
+
+func (s *Server) resolveQuarantined(w http.ResponseWriter, r *http.Request, resolve func(ctx context.Context, id int64) (internal.QuarantinedRate, error)) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid quarantined rate id", http.StatusBadRequest)
+		return
+	}
+
+	rate, err := resolve(s.mainContext, id)
+	switch {
+	case errors.Is(err, internal.ErrQuarantinedRateNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	case errors.Is(err, internal.ErrQuarantinedRateResolved):
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	_ = json.NewEncoder(w).Encode(rate) // handle?
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -12,10 +13,17 @@ import (
 
 	"github.com/fedorov-dmitry/go-test-api/internal"
 	apimocks "github.com/fedorov-dmitry/go-test-api/internal/api/mocks"
+	"github.com/fedorov-dmitry/go-test-api/internal/memory"
 	"github.com/fedorov-dmitry/go-test-api/internal/middleware"
+	"github.com/fedorov-dmitry/go-test-api/internal/mocks"
+	"github.com/shopspring/decimal"
 	"go.uber.org/mock/gomock"
 )
 
+func dec(value string) decimal.Decimal {
+	return decimal.RequireFromString(value)
+}
+
 func TestCurrentRatesHandler_Success(t *testing.T) {
 	t.Parallel()
 
@@ -36,13 +44,13 @@ func TestCurrentRatesHandler_Success(t *testing.T) {
 
 	mockRepo.
 		EXPECT().
-		Get(ctx, base, cur, gomock.Any()).
-		DoAndReturn(func(_ context.Context, _ internal.Currency, _ internal.Currency, _ time.Time) (internal.CurrencyRate, error) {
+		GetLatest(ctx, base, cur, gomock.Any(), defaultMaxStalenessDays).
+		DoAndReturn(func(_ context.Context, _ internal.Currency, _ internal.Currency, _ time.Time, _ int) (internal.CurrencyRate, error) {
 			return internal.CurrencyRate{
 				Date:     time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
 				Base:     base,
 				Currency: cur,
-				Rate:     0.91,
+				Rate:     dec("0.91"),
 			}, nil
 		})
 
@@ -55,7 +63,134 @@ func TestCurrentRatesHandler_Success(t *testing.T) {
 	if err := json.NewDecoder(bytes.NewReader(rr.Body.Bytes())).Decode(&got); err != nil {
 		t.Fatalf("decode: %v", err)
 	}
-	if got.Base != base || got.Currency != cur || got.Rate != 0.91 {
+	if got.Base != base || got.Currency != cur || !got.Rate.Equal(dec("0.91")) {
+		t.Fatalf("unexpected body: %+v", got)
+	}
+}
+
+func TestCurrentRatesHandler_ReturnsAsOfAndUsesMaxStaleness(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := apimocks.NewMockCurrencyRepository(ctrl)
+	ctx := context.Background()
+	logCh := make(chan middleware.RequestLog, 1)
+	s := NewServer(mockRepo, internal.CurrencySynchronizer{}, ctx, logCh, 0, "k", WithMaxStalenessDays(7))
+
+	req := httptest.NewRequest(http.MethodGet, "/rates/latest?base=usd&currency=eur", nil)
+	rr := httptest.NewRecorder()
+
+	asOf := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	mockRepo.
+		EXPECT().
+		GetLatest(ctx, internal.NewCurrency("usd"), internal.NewCurrency("eur"), gomock.Any(), 7).
+		Return(internal.CurrencyRate{Date: asOf, Base: internal.NewCurrency("usd"), Currency: internal.NewCurrency("eur"), Rate: dec("0.91")}, nil)
+
+	s.currentRatesHandler(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status %d, want 200", rr.Code)
+	}
+	var got latestRateResponse
+	if err := json.NewDecoder(bytes.NewReader(rr.Body.Bytes())).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.AsOf != "2025-01-10" || !got.Date.Equal(asOf) {
+		t.Fatalf("unexpected body: %+v", got)
+	}
+}
+
+func TestCurrentRatesHandler_AsKnownAt(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := apimocks.NewMockCurrencyRepository(ctrl)
+	ctx := context.Background()
+	logCh := make(chan middleware.RequestLog, 1)
+	s := NewServer(mockRepo, internal.CurrencySynchronizer{}, ctx, logCh, 0, "k")
+
+	req := httptest.NewRequest(http.MethodGet, "/rates/latest?base=usd&currency=eur&as_known_at=2025-01-14T12:00:00Z", nil)
+	rr := httptest.NewRecorder()
+
+	knownAt := time.Date(2025, 1, 14, 12, 0, 0, 0, time.UTC)
+	mockRepo.
+		EXPECT().
+		GetLatest(gomock.Any(), internal.NewCurrency("usd"), internal.NewCurrency("eur"), knownAt, defaultMaxStalenessDays).
+		DoAndReturn(func(ctx context.Context, base internal.Currency, currency internal.Currency, date time.Time, maxStalenessDays int) (internal.CurrencyRate, error) {
+			if asKnownAt, ok := internal.AsKnownAt(ctx); !ok || !asKnownAt.Equal(knownAt) {
+				t.Fatalf("as_known_at not passed through the context: %v", asKnownAt)
+			}
+			return internal.CurrencyRate{Date: time.Date(2025, 1, 14, 0, 0, 0, 0, time.UTC), Base: base, Currency: currency, Rate: dec("0.9")}, nil
+		})
+
+	s.currentRatesHandler(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status %d, want 200", rr.Code)
+	}
+}
+
+func TestCurrentRatesHandler_InvalidAsKnownAt(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := apimocks.NewMockCurrencyRepository(ctrl)
+	ctx := context.Background()
+	logCh := make(chan middleware.RequestLog, 1)
+	s := NewServer(mockRepo, internal.CurrencySynchronizer{}, ctx, logCh, 0, "k")
+
+	req := httptest.NewRequest(http.MethodGet, "/rates/latest?base=usd&currency=eur&as_known_at=yesterday", nil)
+	rr := httptest.NewRecorder()
+
+	s.currentRatesHandler(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("status %d, want 400", rr.Code)
+	}
+}
+
+func TestRevisionsHandler_Success(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := apimocks.NewMockCurrencyRepository(ctrl)
+	ctx := context.Background()
+	logCh := make(chan middleware.RequestLog, 1)
+	s := NewServer(mockRepo, internal.CurrencySynchronizer{}, ctx, logCh, 0, "k")
+
+	date := time.Date(2025, 1, 14, 0, 0, 0, 0, time.UTC)
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+	revisions := []internal.CurrencyRateRevision{
+		{CurrencyRate: internal.CurrencyRate{Date: date, Base: usd, Currency: eur, Rate: dec("0.91")}, RecordedAt: date.Add(time.Minute)},
+		{CurrencyRate: internal.CurrencyRate{Date: date, Base: usd, Currency: eur, Rate: dec("0.92")}, RecordedAt: date.Add(time.Hour)},
+	}
+	mockRepo.
+		EXPECT().
+		GetRevisions(ctx, usd, eur, date).
+		Return(revisions, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/rates/revisions?base=usd&currency=eur&date=2025-01-14", nil)
+	rr := httptest.NewRecorder()
+
+	s.revisionsHandler(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status %d, want 200", rr.Code)
+	}
+	var got []internal.CurrencyRateRevision
+	if err := json.NewDecoder(bytes.NewReader(rr.Body.Bytes())).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(got) != 2 || !got[1].Rate.Equal(dec("0.92")) || !got[1].RecordedAt.Equal(date.Add(time.Hour)) {
 		t.Fatalf("unexpected body: %+v", got)
 	}
 }
@@ -95,7 +230,7 @@ func TestCurrentRatesHandler_RepoError(t *testing.T) {
 
 	mockRepo.
 		EXPECT().
-		Get(ctx, internal.NewCurrency("usd"), internal.NewCurrency("eur"), gomock.Any()).
+		GetLatest(ctx, internal.NewCurrency("usd"), internal.NewCurrency("eur"), gomock.Any(), gomock.Any()).
 		Return(internal.CurrencyRate{}, errors.New("db error"))
 
 	s.currentRatesHandler(rr, req)
@@ -104,6 +239,30 @@ func TestCurrentRatesHandler_RepoError(t *testing.T) {
 	}
 }
 
+func TestCurrentRatesHandler_NotFound(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := apimocks.NewMockCurrencyRepository(ctrl)
+	ctx := context.Background()
+	logCh := make(chan middleware.RequestLog, 1)
+	s := NewServer(mockRepo, internal.CurrencySynchronizer{}, ctx, logCh, 0, "k")
+
+	req := httptest.NewRequest(http.MethodGet, "/rates/latest?base=usd&currency=xyz", nil)
+	rr := httptest.NewRecorder()
+
+	mockRepo.
+		EXPECT().
+		GetLatest(ctx, internal.NewCurrency("usd"), internal.NewCurrency("xyz"), gomock.Any(), gomock.Any()).
+		Return(internal.CurrencyRate{}, internal.ErrRateNotFound)
+
+	s.currentRatesHandler(rr, req)
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("status %d, want 404", rr.Code)
+	}
+}
+
 func TestHistoricalRatesHandler_Success(t *testing.T) {
 	t.Parallel()
 
@@ -122,7 +281,7 @@ func TestHistoricalRatesHandler_Success(t *testing.T) {
 		EXPECT().
 		GetMany(ctx, internal.NewCurrency("usd"), date).
 		Return([]internal.CurrencyRate{
-			{Date: date, Base: internal.NewCurrency("usd"), Currency: internal.NewCurrency("eur"), Rate: 0.92},
+			{Date: date, Base: internal.NewCurrency("usd"), Currency: internal.NewCurrency("eur"), Rate: dec("0.92")},
 		}, nil)
 
 	s.historicalRatesHandler(rr, req)
@@ -157,55 +316,887 @@ func TestHistoricalRatesHandler_InvalidDate(t *testing.T) {
 	}
 }
 
-func TestGetHandlers_WithMiddleware(t *testing.T) {
+func TestTimeseriesRatesHandler_Success(t *testing.T) {
 	t.Parallel()
+
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-
 	mockRepo := apimocks.NewMockCurrencyRepository(ctrl)
 	ctx := context.Background()
-	logCh := make(chan middleware.RequestLog, 10)
-	s := NewServer(mockRepo, internal.CurrencySynchronizer{}, ctx, logCh, 0, "secret")
+	logCh := make(chan middleware.RequestLog, 1)
+	s := NewServer(mockRepo, internal.CurrencySynchronizer{}, ctx, logCh, 0, "k")
 
-	// Setup repo expectation for authorized request
+	req := httptest.NewRequest(http.MethodGet, "/rates/timeseries?base=usd&currency=eur&from=2025-01-13&to=2025-01-14", nil)
+	rr := httptest.NewRecorder()
+
+	from := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 14, 0, 0, 0, 0, time.UTC)
 	mockRepo.
 		EXPECT().
-		Get(ctx, internal.NewCurrency("usd"), internal.NewCurrency("eur"), gomock.Any()).
-		Return(internal.CurrencyRate{Base: internal.NewCurrency("usd"), Currency: internal.NewCurrency("eur"), Rate: 1.0}, nil).
-		Times(1)
-
-	ts := httptest.NewServer(s.getHandlers())
-	defer ts.Close()
+		GetRange(ctx, internal.NewCurrency("usd"), internal.NewCurrency("eur"), from, to).
+		Return([]internal.CurrencyRate{
+			{Date: from, Base: internal.NewCurrency("usd"), Currency: internal.NewCurrency("eur"), Rate: dec("0.92")},
+			{Date: to, Base: internal.NewCurrency("usd"), Currency: internal.NewCurrency("eur"), Rate: dec("0.93")},
+		}, nil)
 
-	// Unauthorized request
-	resp, err := http.Get(ts.URL + "/rates/latest?base=usd&currency=eur")
-	if err != nil {
-		t.Fatalf("http get: %v", err)
+	s.timeseriesRatesHandler(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status %d, want 200", rr.Code)
 	}
-	if resp.StatusCode != http.StatusUnauthorized {
-		t.Fatalf("unauthorized status %d, want 401", resp.StatusCode)
+	var got []internal.CurrencyRate
+	if err := json.NewDecoder(bytes.NewReader(rr.Body.Bytes())).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
 	}
-	_ = resp.Body.Close()
+	if len(got) != 2 || !got[0].Date.Equal(from) || !got[1].Date.Equal(to) {
+		t.Fatalf("unexpected body: %+v", got)
+	}
+}
 
-	// Authorized request
-	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/rates/latest?base=usd&currency=eur", nil)
-	req.Header.Set("Authorization", "secret")
-	resp2, err := http.DefaultClient.Do(req)
-	if err != nil {
-		t.Fatalf("http do: %v", err)
+func TestTimeseriesRatesHandler_InvalidRange(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := apimocks.NewMockCurrencyRepository(ctrl)
+	ctx := context.Background()
+	logCh := make(chan middleware.RequestLog, 1)
+	s := NewServer(mockRepo, internal.CurrencySynchronizer{}, ctx, logCh, 0, "k")
+
+	req := httptest.NewRequest(http.MethodGet, "/rates/timeseries?base=usd&currency=eur&from=2025-01-14&to=2025-01-13", nil)
+	rr := httptest.NewRecorder()
+
+	s.timeseriesRatesHandler(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("status %d, want 400", rr.Code)
 	}
-	if resp2.StatusCode != http.StatusOK {
-		t.Fatalf("authorized status %d, want 200", resp2.StatusCode)
+}
+
+func TestConvertHandler_Success(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := apimocks.NewMockCurrencyRepository(ctrl)
+	ctx := context.Background()
+	logCh := make(chan middleware.RequestLog, 1)
+	s := NewServer(mockRepo, internal.CurrencySynchronizer{}, ctx, logCh, 0, "k")
+
+	req := httptest.NewRequest(http.MethodGet, "/convert?from=USD&to=jpy&amount=10.5&date=2025-01-13", nil)
+	rr := httptest.NewRecorder()
+
+	date := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)
+	mockRepo.
+		EXPECT().
+		Get(ctx, internal.NewCurrency("usd"), internal.NewCurrency("jpy"), date).
+		Return(internal.CurrencyRate{Date: date, Base: internal.NewCurrency("usd"), Currency: internal.NewCurrency("jpy"), Rate: dec("145.1")}, nil)
+
+	s.convertHandler(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status %d, want 200", rr.Code)
 	}
-	_ = resp2.Body.Close()
+	var got internal.Conversion
+	if err := json.NewDecoder(bytes.NewReader(rr.Body.Bytes())).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !got.Result.Equal(dec("1524")) || !got.Rate.Equal(dec("145.1")) || !got.Amount.Equal(dec("10.5")) || !got.Date.Equal(date) {
+		t.Fatalf("unexpected body: %+v", got)
+	}
+}
 
-	// Logging middleware should emit a log entry
-	select {
-	case entry := <-logCh:
-		if entry.Path != "/rates/latest" {
-			t.Fatalf("unexpected log path: %s", entry.Path)
+func TestConvertHandler_InvalidAmount(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := apimocks.NewMockCurrencyRepository(ctrl)
+	ctx := context.Background()
+	logCh := make(chan middleware.RequestLog, 1)
+	s := NewServer(mockRepo, internal.CurrencySynchronizer{}, ctx, logCh, 0, "k")
+
+	amounts := []string{"abc", "1e100000000", "1e-100000000", "123456789012345678901234567890123456789012345"}
+	for _, amount := range amounts {
+		req := httptest.NewRequest(http.MethodGet, "/convert?from=usd&to=eur&amount="+amount, nil)
+		rr := httptest.NewRecorder()
+
+		s.convertHandler(rr, req)
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("amount %s: status %d, want 400", amount, rr.Code)
 		}
-	case <-time.After(2 * time.Second):
-		t.Fatal("expected a log entry, got none")
+	}
+}
+
+func TestSyncRunsHandler_Success(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := apimocks.NewMockCurrencyRepository(ctrl)
+	mockRuns := apimocks.NewMockSyncRunRepository(ctrl)
+	ctx := context.Background()
+	logCh := make(chan middleware.RequestLog, 1)
+	s := NewServer(mockRepo, internal.CurrencySynchronizer{}, ctx, logCh, 0, "k", WithSyncRuns(mockRuns))
+
+	req := httptest.NewRequest(http.MethodGet, "/sync/runs?limit=2", nil)
+	rr := httptest.NewRecorder()
+
+	mockRuns.
+		EXPECT().
+		List(ctx, 2).
+		Return([]internal.SyncRun{{ID: 2, Status: internal.SyncRunStatusFailed}, {ID: 1, Status: internal.SyncRunStatusSucceeded}}, nil)
+
+	s.syncRunsHandler(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status %d, want 200", rr.Code)
+	}
+	var got []internal.SyncRun
+	if err := json.NewDecoder(bytes.NewReader(rr.Body.Bytes())).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != 2 || got[1].Status != internal.SyncRunStatusSucceeded {
+		t.Fatalf("unexpected body: %+v", got)
+	}
+}
+
+func TestSyncRunsHandler_InvalidLimit(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := apimocks.NewMockCurrencyRepository(ctrl)
+	mockRuns := apimocks.NewMockSyncRunRepository(ctrl)
+	ctx := context.Background()
+	logCh := make(chan middleware.RequestLog, 1)
+	s := NewServer(mockRepo, internal.CurrencySynchronizer{}, ctx, logCh, 0, "k", WithSyncRuns(mockRuns))
+
+	req := httptest.NewRequest(http.MethodGet, "/sync/runs?limit=0", nil)
+	rr := httptest.NewRecorder()
+
+	s.syncRunsHandler(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("status %d, want 400", rr.Code)
+	}
+}
+
+func TestSyncStatusHandler_Success(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := apimocks.NewMockCurrencyRepository(ctrl)
+	mockRuns := apimocks.NewMockSyncRunRepository(ctrl)
+	ctx := context.Background()
+	logCh := make(chan middleware.RequestLog, 1)
+	s := NewServer(mockRepo, internal.CurrencySynchronizer{}, ctx, logCh, 0, "k", WithSyncRuns(mockRuns))
+
+	req := httptest.NewRequest(http.MethodGet, "/sync/status", nil)
+	rr := httptest.NewRecorder()
+
+	finishedAt := time.Date(2025, 1, 13, 12, 0, 0, 0, time.UTC)
+	mockRuns.
+		EXPECT().
+		Status(ctx).
+		Return(internal.SyncStatus{
+			LastRun:           &internal.SyncRun{ID: 2, Status: internal.SyncRunStatusFailed},
+			LastSuccessfulRun: &internal.SyncRun{ID: 1, Status: internal.SyncRunStatusSucceeded, FinishedAt: &finishedAt},
+		}, nil)
+
+	s.syncStatusHandler(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status %d, want 200", rr.Code)
+	}
+	var got internal.SyncStatus
+	if err := json.NewDecoder(bytes.NewReader(rr.Body.Bytes())).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.LastRun == nil || got.LastRun.ID != 2 || got.LastSuccessfulRun == nil || !got.LastSuccessfulRun.FinishedAt.Equal(finishedAt) {
+		t.Fatalf("unexpected body: %+v", got)
+	}
+}
+
+func TestAdminBackfill_RunsJobAndReportsProgress(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := apimocks.NewMockCurrencyRepository(ctrl)
+	mockSource := mocks.NewMockCurrencyRateSource(ctrl)
+	mockStorage := mocks.NewMockCurrencyStorage(ctrl)
+
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+	synchronizer := internal.NewCurrencySynchronizer(*internal.NewCurrencyRepository(mockStorage), mockSource, []internal.Currency{usd, eur})
+
+	ctx := context.Background()
+	logCh := make(chan middleware.RequestLog, 10)
+	s := NewServer(mockRepo, *synchronizer, ctx, logCh, 0, "k", WithAdminApiKey("admin"))
+
+	mockSource.
+		EXPECT().
+		Get(gomock.Any(), usd, []internal.Currency{eur}, gomock.Any()).
+		Return([]internal.CurrencyRate{{Currency: eur, Rate: dec("0.92")}}, nil).
+		Times(2)
+	mockStorage.
+		EXPECT().
+		SetMany(gomock.Any(), gomock.Any()).
+		Return(nil).
+		Times(2)
+
+	ts := httptest.NewServer(s.getHandlers())
+	defer ts.Close()
+
+	// Regular API key is not enough for admin endpoints
+	req, _ := http.NewRequest(http.MethodPost, ts.URL+"/admin/backfill?from=2025-01-13&to=2025-01-14&bases=USD", nil)
+	req.Header.Set("Authorization", "k")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("http do: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("status %d, want 401", resp.StatusCode)
+	}
+
+	req, _ = http.NewRequest(http.MethodPost, ts.URL+"/admin/backfill?from=2025-01-13&to=2025-01-14&bases=USD", nil)
+	req.Header.Set("Authorization", "admin")
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("http do: %v", err)
+	}
+	var job internal.SyncJob
+	if err := json.NewDecoder(resp.Body).Decode(&job); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted || job.ID == "" || job.TotalUnits != 2 || job.Trigger != internal.SyncTriggerBackfill {
+		t.Fatalf("unexpected response %d: %+v", resp.StatusCode, job)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for job.FinishedAt == nil {
+		if time.Now().After(deadline) {
+			t.Fatalf("job did not finish: %+v", job)
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		req, _ = http.NewRequest(http.MethodGet, ts.URL+"/admin/jobs/"+job.ID, nil)
+		req.Header.Set("Authorization", "admin")
+		resp, err = http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("http do: %v", err)
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&job); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	if job.Status != internal.SyncRunStatusSucceeded || job.CompletedUnits != 2 || job.RowsWritten != 2 {
+		t.Fatalf("unexpected job: %+v", job)
+	}
+}
+
+func TestAdminBackfillHandler_UnknownBase(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := apimocks.NewMockCurrencyRepository(ctrl)
+	synchronizer := internal.NewCurrencySynchronizer(internal.CurrencyRepository{}, nil, []internal.Currency{internal.NewCurrency("usd")})
+	ctx := context.Background()
+	logCh := make(chan middleware.RequestLog, 1)
+	s := NewServer(mockRepo, *synchronizer, ctx, logCh, 0, "k", WithAdminApiKey("admin"))
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/backfill?from=2025-01-13&to=2025-01-14&bases=xyz", nil)
+	rr := httptest.NewRecorder()
+
+	s.adminBackfillHandler(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("status %d, want 400", rr.Code)
+	}
+}
+
+func TestAdminBackfillHandler_RejectsWhileAJobIsRunning(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := apimocks.NewMockCurrencyRepository(ctrl)
+	mockSource := mocks.NewMockCurrencyRateSource(ctrl)
+	mockStorage := mocks.NewMockCurrencyStorage(ctrl)
+
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+	synchronizer := internal.NewCurrencySynchronizer(*internal.NewCurrencyRepository(mockStorage), mockSource, []internal.Currency{usd, eur})
+
+	ctx := context.Background()
+	logCh := make(chan middleware.RequestLog, 1)
+	s := NewServer(mockRepo, *synchronizer, ctx, logCh, 0, "k", WithAdminApiKey("admin"))
+
+	release := make(chan struct{})
+	mockSource.
+		EXPECT().
+		Get(gomock.Any(), usd, []internal.Currency{eur}, gomock.Any()).
+		DoAndReturn(func(context.Context, internal.Currency, []internal.Currency, time.Time) ([]internal.CurrencyRate, error) {
+			<-release
+			return []internal.CurrencyRate{{Currency: eur, Rate: dec("0.92")}}, nil
+		})
+	mockStorage.EXPECT().SetMany(gomock.Any(), gomock.Any()).Return(nil)
+
+	rr := httptest.NewRecorder()
+	s.adminBackfillHandler(rr, httptest.NewRequest(http.MethodPost, "/admin/backfill?from=2025-01-13&to=2025-01-13&bases=usd", nil))
+	if rr.Code != http.StatusAccepted {
+		t.Fatalf("status %d, want 202", rr.Code)
+	}
+	var job internal.SyncJob
+	if err := json.NewDecoder(rr.Body).Decode(&job); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	rr = httptest.NewRecorder()
+	s.adminBackfillHandler(rr, httptest.NewRequest(http.MethodPost, "/admin/backfill?from=2025-01-13&to=2025-01-13&bases=usd", nil))
+	if rr.Code != http.StatusConflict {
+		t.Fatalf("status %d, want 409", rr.Code)
+	}
+
+	close(release)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for job.FinishedAt == nil {
+		if time.Now().After(deadline) {
+			t.Fatalf("job did not finish: %+v", job)
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		var err error
+		job, err = s.jobs.Get(job.ID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func TestAdminJobHandler_NotFound(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := apimocks.NewMockCurrencyRepository(ctrl)
+	ctx := context.Background()
+	logCh := make(chan middleware.RequestLog, 1)
+	s := NewServer(mockRepo, internal.CurrencySynchronizer{}, ctx, logCh, 0, "k", WithAdminApiKey("admin"))
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/jobs/missing", nil)
+	req.SetPathValue("id", "missing")
+	rr := httptest.NewRecorder()
+
+	s.adminJobHandler(rr, req)
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("status %d, want 404", rr.Code)
+	}
+}
+
+func TestAdminGapsHandler_Success(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := apimocks.NewMockCurrencyRepository(ctrl)
+	mockStorage := mocks.NewMockCurrencyStorage(ctrl)
+
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+	synchronizer := internal.NewCurrencySynchronizer(*internal.NewCurrencyRepository(mockStorage), nil, []internal.Currency{usd, eur}, internal.WithRetentionDays(1))
+
+	ctx := context.Background()
+	logCh := make(chan middleware.RequestLog, 1)
+	s := NewServer(mockRepo, *synchronizer, ctx, logCh, 0, "k", WithAdminApiKey("admin"))
+
+	today, _ := time.Parse("2006-01-02", time.Now().Format("2006-01-02"))
+	yesterday := today.AddDate(0, 0, -1)
+	mockStorage.
+		EXPECT().
+		GetKeys(ctx, yesterday, yesterday).
+		Return([]internal.CurrencyRateKey{{Date: yesterday, Base: usd, Currency: eur}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/gaps", nil)
+	rr := httptest.NewRecorder()
+
+	s.adminGapsHandler(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status %d, want 200", rr.Code)
+	}
+	var got internal.GapReport
+	if err := json.NewDecoder(bytes.NewReader(rr.Body.Bytes())).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.MissingRates != 1 || len(got.Gaps) != 1 || got.Gaps[0].Base != eur || got.Gaps[0].Missing[0] != usd {
+		t.Fatalf("unexpected body: %+v", got)
+	}
+}
+
+func TestAdminQuarantineHandler_ListsPending(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := apimocks.NewMockCurrencyRepository(ctrl)
+	mockQuarantine := apimocks.NewMockQuarantineRepository(ctrl)
+	ctx := context.Background()
+	logCh := make(chan middleware.RequestLog, 1)
+	s := NewServer(mockRepo, internal.CurrencySynchronizer{}, ctx, logCh, 0, "k", WithAdminApiKey("admin"), WithQuarantine(mockQuarantine))
+
+	want := []internal.QuarantinedRate{{ID: 7, Rate: internal.CurrencyRate{Base: "usd", Currency: "jpy", Rate: dec("145100")}, PreviousRate: dec("145.1"), Status: internal.QuarantineStatusPending}}
+	mockQuarantine.
+		EXPECT().
+		List(ctx, internal.QuarantineStatusPending, 50).
+		Return(want, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/quarantine", nil)
+	rr := httptest.NewRecorder()
+
+	s.adminQuarantineHandler(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status %d, want 200", rr.Code)
+	}
+	var got []internal.QuarantinedRate
+	if err := json.NewDecoder(bytes.NewReader(rr.Body.Bytes())).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != 7 || !got[0].Rate.Rate.Equal(dec("145100")) {
+		t.Fatalf("unexpected body: %+v", got)
+	}
+}
+
+func TestAdminApproveQuarantinedHandler_AlreadyResolved(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := apimocks.NewMockCurrencyRepository(ctrl)
+	mockQuarantine := apimocks.NewMockQuarantineRepository(ctrl)
+	ctx := context.Background()
+	logCh := make(chan middleware.RequestLog, 1)
+	s := NewServer(mockRepo, internal.CurrencySynchronizer{}, ctx, logCh, 0, "k", WithAdminApiKey("admin"), WithQuarantine(mockQuarantine))
+
+	mockQuarantine.
+		EXPECT().
+		Approve(ctx, int64(7)).
+		Return(internal.QuarantinedRate{}, fmt.Errorf("quarantined currency rate 7 is rejected: %w", internal.ErrQuarantinedRateResolved))
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/quarantine/7/approve", nil)
+	req.SetPathValue("id", "7")
+	rr := httptest.NewRecorder()
+
+	s.adminApproveQuarantinedHandler(rr, req)
+	if rr.Code != http.StatusConflict {
+		t.Fatalf("status %d, want 409", rr.Code)
+	}
+}
+
+func TestServer_WithMemoryStorage(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSource := mocks.NewMockCurrencyRateSource(ctrl)
+
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+	jpy := internal.NewCurrency("jpy")
+	date := time.Now().UTC().Truncate(24 * time.Hour)
+
+	repository := internal.NewCurrencyRepository(memory.NewCurrencyStorage(), usd)
+	synchronizer := internal.NewCurrencySynchronizer(*repository, mockSource, []internal.Currency{usd, eur, jpy})
+
+	mockSource.
+		EXPECT().
+		Get(gomock.Any(), usd, []internal.Currency{eur, jpy}, date).
+		Return([]internal.CurrencyRate{{Currency: eur, Rate: dec("0.92")}, {Currency: jpy, Rate: dec("145.1")}}, nil)
+
+	ctx := context.Background()
+	report := synchronizer.Run(ctx, internal.SyncTriggerManual, []internal.SyncUnit{{Base: usd, Date: date}})
+	if len(report.Failed) != 0 {
+		t.Fatalf("unexpected failures: %+v", report.Failed)
+	}
+
+	logCh := make(chan middleware.RequestLog, 10)
+	s := NewServer(repository, *synchronizer, ctx, logCh, 0, "k")
+
+	rr := httptest.NewRecorder()
+	s.historicalRatesHandler(rr, httptest.NewRequest(http.MethodGet, "/rates/historical?base=usd&date="+date.Format("2006-01-02"), nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status %d, want 200", rr.Code)
+	}
+	var rates []internal.CurrencyRate
+	if err := json.NewDecoder(rr.Body).Decode(&rates); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(rates) != 2 || rates[0].Currency != eur || rates[1].Currency != jpy {
+		t.Fatalf("unexpected body: %+v", rates)
+	}
+
+	// eur-jpy is not stored and is triangulated through usd
+	rr = httptest.NewRecorder()
+	s.currentRatesHandler(rr, httptest.NewRequest(http.MethodGet, "/rates/latest?base=eur&currency=jpy", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status %d, want 200: %s", rr.Code, rr.Body.String())
+	}
+	var rate internal.CurrencyRate
+	if err := json.NewDecoder(rr.Body).Decode(&rate); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !rate.Date.Equal(date) || !rate.Derived || rate.Rate.Sub(dec("145.1").Div(dec("0.92"))).Abs().GreaterThan(dec("1e-9")) {
+		t.Fatalf("unexpected body: %+v", rate)
+	}
+}
+
+func TestAdminCacheHandler_ReportsStats(t *testing.T) {
+	t.Parallel()
+
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+	date := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)
+
+	ctx := context.Background()
+	cache := internal.NewCachedCurrencyStorage(memory.NewCurrencyStorage(), 10, time.Minute, time.Hour)
+	if err := cache.Set(ctx, internal.CurrencyRate{Date: date, Base: usd, Currency: eur, Rate: dec("0.92")}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	logCh := make(chan middleware.RequestLog, 10)
+	s := NewServer(internal.NewCurrencyRepository(cache), internal.CurrencySynchronizer{}, ctx, logCh, 0, "k", WithAdminApiKey("admin"), WithCache(cache))
+
+	for range 2 {
+		rr := httptest.NewRecorder()
+		s.historicalRatesHandler(rr, httptest.NewRequest(http.MethodGet, "/rates/historical?base=usd&date=2025-01-13", nil))
+		if rr.Code != http.StatusOK {
+			t.Fatalf("status %d, want 200", rr.Code)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/cache", nil)
+	req.Header.Set("Authorization", "admin")
+	rr := httptest.NewRecorder()
+
+	s.getHandlers().ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status %d, want 200", rr.Code)
+	}
+	var got internal.CacheStats
+	if err := json.NewDecoder(rr.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.Entries != 1 || got.Capacity != 10 || got.Hits != 1 || got.Misses != 1 {
+		t.Fatalf("unexpected body: %+v", got)
+	}
+}
+
+func TestCurrenciesHandler_FiltersByKindAndActive(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCatalog := apimocks.NewMockCurrencyCatalog(ctrl)
+	ctx := context.Background()
+	logCh := make(chan middleware.RequestLog, 1)
+	s := NewServer(apimocks.NewMockCurrencyRepository(ctrl), internal.CurrencySynchronizer{}, ctx, logCh, 0, "k", WithCurrencyCatalog(mockCatalog))
+
+	mockCatalog.
+		EXPECT().
+		List().
+		Return([]internal.CurrencyInfo{
+			{Code: "btc", Name: "Bitcoin", MinorUnits: 8, Active: true, Kind: internal.CurrencyKindCrypto},
+			{Code: "hrk", NumericCode: "191", Name: "Kuna", MinorUnits: 2, Kind: internal.CurrencyKindFiat},
+			{Code: "usd", NumericCode: "840", Name: "US Dollar", MinorUnits: 2, Active: true, Kind: internal.CurrencyKindFiat},
+		}).
+		Times(2)
+
+	for query, want := range map[string][]internal.Currency{
+		"/currencies?kind=fiat":                       {"usd"},
+		"/currencies?kind=fiat&include_inactive=true": {"hrk", "usd"},
+	} {
+		rr := httptest.NewRecorder()
+		s.currenciesHandler(rr, httptest.NewRequest(http.MethodGet, query, nil))
+		if rr.Code != http.StatusOK {
+			t.Fatalf("%s: status %d, want 200", query, rr.Code)
+		}
+
+		var got []internal.CurrencyInfo
+		if err := json.NewDecoder(rr.Body).Decode(&got); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if len(got) != len(want) {
+			t.Fatalf("%s: got %+v, want %v", query, got, want)
+		}
+		for i := range want {
+			if got[i].Code != want[i] {
+				t.Fatalf("%s: got %+v, want %v", query, got, want)
+			}
+		}
+	}
+
+	rr := httptest.NewRecorder()
+	s.currenciesHandler(rr, httptest.NewRequest(http.MethodGet, "/currencies?kind=metal", nil))
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("status %d, want 400", rr.Code)
+	}
+}
+
+func TestConvertHandler_UnknownCurrency(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := apimocks.NewMockCurrencyRepository(ctrl)
+	mockCatalog := apimocks.NewMockCurrencyCatalog(ctrl)
+	ctx := context.Background()
+	logCh := make(chan middleware.RequestLog, 1)
+	s := NewServer(mockRepo, internal.CurrencySynchronizer{}, ctx, logCh, 0, "k", WithCurrencyCatalog(mockCatalog))
+
+	mockCatalog.EXPECT().Get(internal.NewCurrency("usd")).Return(internal.CurrencyInfo{Code: "usd", MinorUnits: 2, Active: true}, nil)
+	mockCatalog.EXPECT().Get(internal.NewCurrency("usdd")).Return(internal.CurrencyInfo{}, fmt.Errorf("%w: %q", internal.ErrUnknownCurrency, "usdd"))
+
+	rr := httptest.NewRecorder()
+	s.convertHandler(rr, httptest.NewRequest(http.MethodGet, "/convert?from=usd&to=USDD&amount=10", nil))
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("status %d, want 400", rr.Code)
+	}
+}
+
+func TestConvertHandler_InactiveCurrencyOnlyWithDate(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := apimocks.NewMockCurrencyRepository(ctrl)
+	mockCatalog := apimocks.NewMockCurrencyCatalog(ctrl)
+	ctx := context.Background()
+	logCh := make(chan middleware.RequestLog, 1)
+	s := NewServer(mockRepo, internal.CurrencySynchronizer{}, ctx, logCh, 0, "k", WithCurrencyCatalog(mockCatalog))
+
+	eur := internal.NewCurrency("eur")
+	hrk := internal.NewCurrency("hrk")
+	date := time.Date(2022, 12, 30, 0, 0, 0, 0, time.UTC)
+	mockCatalog.EXPECT().Get(eur).Return(internal.CurrencyInfo{Code: eur, MinorUnits: 2, Active: true}, nil).AnyTimes()
+	mockCatalog.EXPECT().Get(hrk).Return(internal.CurrencyInfo{Code: hrk, MinorUnits: 2, Active: false}, nil).AnyTimes()
+
+	rr := httptest.NewRecorder()
+	s.convertHandler(rr, httptest.NewRequest(http.MethodGet, "/convert?from=eur&to=hrk&amount=10", nil))
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("status %d, want 400", rr.Code)
+	}
+
+	mockRepo.EXPECT().Get(ctx, eur, hrk, date).Return(internal.CurrencyRate{Date: date, Base: eur, Currency: hrk, Rate: decimal.RequireFromString("7.5345")}, nil)
+
+	rr = httptest.NewRecorder()
+	s.convertHandler(rr, httptest.NewRequest(http.MethodGet, "/convert?from=eur&to=hrk&amount=10&date=2022-12-30", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status %d, want 200: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestGetHandlers_WithMiddleware(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := apimocks.NewMockCurrencyRepository(ctrl)
+	ctx := context.Background()
+	logCh := make(chan middleware.RequestLog, 10)
+	s := NewServer(mockRepo, internal.CurrencySynchronizer{}, ctx, logCh, 0, "secret")
+
+	// Setup repo expectation for authorized request
+	mockRepo.
+		EXPECT().
+		GetLatest(ctx, internal.NewCurrency("usd"), internal.NewCurrency("eur"), gomock.Any(), gomock.Any()).
+		Return(internal.CurrencyRate{Base: internal.NewCurrency("usd"), Currency: internal.NewCurrency("eur"), Rate: dec("1.0")}, nil).
+		Times(1)
+
+	ts := httptest.NewServer(s.getHandlers())
+	defer ts.Close()
+
+	// Unauthorized request
+	resp, err := http.Get(ts.URL + "/rates/latest?base=usd&currency=eur")
+	if err != nil {
+		t.Fatalf("http get: %v", err)
+	}
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("unauthorized status %d, want 401", resp.StatusCode)
+	}
+	_ = resp.Body.Close()
+
+	// Authorized request
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/rates/latest?base=usd&currency=eur", nil)
+	req.Header.Set("Authorization", "secret")
+	resp2, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("http do: %v", err)
+	}
+	if resp2.StatusCode != http.StatusOK {
+		t.Fatalf("authorized status %d, want 200", resp2.StatusCode)
+	}
+	_ = resp2.Body.Close()
+
+	// Logging middleware should emit a log entry
+	select {
+	case entry := <-logCh:
+		if entry.Path != "/rates/latest" {
+			t.Fatalf("unexpected log path: %s", entry.Path)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected a log entry, got none")
+	}
+}
+
+func TestAdminTrackCurrencyHandler(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := apimocks.NewMockCurrencyRepository(ctrl)
+	mockTracked := apimocks.NewMockTrackedCurrencyRepository(ctrl)
+	ctx := context.Background()
+	logCh := make(chan middleware.RequestLog, 1)
+	s := NewServer(mockRepo, internal.CurrencySynchronizer{}, ctx, logCh, 0, "k", WithAdminApiKey("admin"), WithTrackedCurrencies(mockTracked))
+
+	addedAt := time.Date(2025, 1, 13, 10, 0, 0, 0, time.UTC)
+	mockTracked.
+		EXPECT().
+		Add(gomock.Any(), internal.NewCurrency("chf")).
+		Return(internal.TrackedCurrency{Currency: "chf", AddedAt: addedAt}, nil)
+	mockTracked.
+		EXPECT().
+		Add(gomock.Any(), internal.NewCurrency("usd")).
+		Return(internal.TrackedCurrency{}, fmt.Errorf("failed to track usd: %w", internal.ErrCurrencyAlreadyTracked))
+	mockTracked.
+		EXPECT().
+		Remove(gomock.Any(), internal.NewCurrency("jpy")).
+		Return(fmt.Errorf("failed to stop tracking jpy: %w", internal.ErrCurrencyNotTracked))
+
+	rr := httptest.NewRecorder()
+	s.adminTrackCurrencyHandler(rr, httptest.NewRequest(http.MethodPost, "/admin/currencies?currency=CHF", nil))
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("status %d, want 201: %s", rr.Code, rr.Body.String())
+	}
+	var got trackedCurrencyResponse
+	if err := json.NewDecoder(rr.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.Currency != "chf" || !got.AddedAt.Equal(addedAt) || got.Job != nil {
+		t.Fatalf("unexpected body: %+v", got)
+	}
+
+	rr = httptest.NewRecorder()
+	s.adminTrackCurrencyHandler(rr, httptest.NewRequest(http.MethodPost, "/admin/currencies?currency=usd", nil))
+	if rr.Code != http.StatusConflict {
+		t.Fatalf("status %d, want 409", rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	s.adminTrackCurrencyHandler(rr, httptest.NewRequest(http.MethodPost, "/admin/currencies?currency=eur&backfill_days=-1", nil))
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("status %d, want 400", rr.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodDelete, "/admin/currencies/jpy", nil)
+	req.SetPathValue("currency", "jpy")
+	rr = httptest.NewRecorder()
+	s.adminUntrackCurrencyHandler(rr, req)
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("status %d, want 404", rr.Code)
+	}
+}
+
+func TestGetHandlers_WithApiKeys(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := apimocks.NewMockCurrencyRepository(ctrl)
+	mockKeys := apimocks.NewMockApiKeyRepository(ctrl)
+	ctx := context.Background()
+	logCh := make(chan middleware.RequestLog, 10)
+	s := NewServer(mockRepo, internal.CurrencySynchronizer{}, ctx, logCh, 0, "", WithApiKeys(mockKeys))
+
+	mockKeys.
+		EXPECT().
+		Authenticate(gomock.Any(), "gta_reader", internal.ApiKeyScopeReadRates).
+		Return(internal.ApiKey{ID: 1, Scopes: []internal.ApiKeyScope{internal.ApiKeyScopeReadRates}}, nil)
+	mockKeys.
+		EXPECT().
+		Authenticate(gomock.Any(), "gta_reader", internal.ApiKeyScopeAdmin).
+		Return(internal.ApiKey{}, fmt.Errorf("api key 1 cannot be used for admin: %w", internal.ErrApiKeyMissingScope))
+	mockKeys.
+		EXPECT().
+		Authenticate(gomock.Any(), "gta_revoked", internal.ApiKeyScopeReadRates).
+		Return(internal.ApiKey{}, fmt.Errorf("failed to authenticate api key 2: %w", internal.ErrApiKeyRevoked))
+	mockKeys.
+		EXPECT().
+		Authenticate(gomock.Any(), "gta_admin", internal.ApiKeyScopeAdmin).
+		Return(internal.ApiKey{ID: 3, Scopes: []internal.ApiKeyScope{internal.ApiKeyScopeAdmin}}, nil)
+	mockKeys.
+		EXPECT().
+		Create(gomock.Any(), "billing", []internal.ApiKeyScope{internal.ApiKeyScopeReadRates, internal.ApiKeyScopeSync}, nil).
+		Return(internal.ApiKey{ID: 4, Name: "billing", Prefix: "gta_9f86d081", Hash: "hash"}, "gta_9f86d081secret", nil)
+	mockRepo.
+		EXPECT().
+		GetLatest(gomock.Any(), internal.NewCurrency("usd"), internal.NewCurrency("eur"), gomock.Any(), gomock.Any()).
+		Return(internal.CurrencyRate{Base: "usd", Currency: "eur", Rate: dec("0.92")}, nil)
+
+	ts := httptest.NewServer(s.getHandlers())
+	defer ts.Close()
+
+	do := func(method string, path string, apiKey string) *http.Response {
+		req, _ := http.NewRequest(method, ts.URL+path, nil)
+		if apiKey != "" {
+			req.Header.Set("Authorization", apiKey)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("http do: %v", err)
+		}
+		t.Cleanup(func() { _ = resp.Body.Close() })
+		return resp
+	}
+
+	// an empty API_KEY no longer lets anonymous requests through
+	if resp := do(http.MethodGet, "/rates/latest?base=usd&currency=eur", ""); resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("anonymous status %d, want 401", resp.StatusCode)
+	}
+	if resp := do(http.MethodGet, "/rates/latest?base=usd&currency=eur", "gta_reader"); resp.StatusCode != http.StatusOK {
+		t.Fatalf("reader status %d, want 200", resp.StatusCode)
+	}
+	if resp := do(http.MethodGet, "/admin/keys", "gta_reader"); resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("reader admin status %d, want 403", resp.StatusCode)
+	}
+	if resp := do(http.MethodGet, "/rates/latest?base=usd&currency=eur", "gta_revoked"); resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("revoked status %d, want 401", resp.StatusCode)
+	}
+
+	resp := do(http.MethodPost, "/admin/keys?name=billing&scopes=rates:read,sync,rates:read", "gta_admin")
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("create status %d, want 201", resp.StatusCode)
+	}
+	var created map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if created["Key"] != "gta_9f86d081secret" || created["Prefix"] != "gta_9f86d081" {
+		t.Fatalf("unexpected body: %+v", created)
+	}
+	if _, ok := created["Hash"]; ok {
+		t.Fatalf("the key hash must not be returned: %+v", created)
 	}
 }
@@ -0,0 +1,254 @@
+package internal
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+var (
+	ErrApiKeyNotFound     = errors.New("api key not found")
+	ErrApiKeyRevoked      = errors.New("api key revoked")
+	ErrApiKeyExpired      = errors.New("api key expired")
+	ErrApiKeyMissingScope = errors.New("api key is missing the required scope")
+	ErrUnknownApiKeyScope = errors.New("unknown api key scope")
+)
+
+const (
+	apiKeyPrefix       = "gta_"
+	apiKeySecretBytes  = 32
+	apiKeyDisplayChars = 12
+	apiKeyCacheTTL     = 30 * time.Second
+)
+
+type ApiKeyScope string
+
+const (
+	ApiKeyScopeReadRates ApiKeyScope = "rates:read"
+	ApiKeyScopeSync      ApiKeyScope = "sync"
+	ApiKeyScopeAdmin     ApiKeyScope = "admin"
+)
+
+func ParseApiKeyScope(str string) (ApiKeyScope, error) {
+	scope := ApiKeyScope(str)
+	switch scope {
+	case ApiKeyScopeReadRates, ApiKeyScopeSync, ApiKeyScopeAdmin:
+		return scope, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownApiKeyScope, str)
+	}
+}
+
+type ApiKey struct {
+	ID        int64
+	Name      string
+	Prefix    string
+	Hash      string `json:"-"`
+	Scopes    []ApiKeyScope
+	CreatedAt time.Time
+	ExpiresAt *time.Time
+	RevokedAt *time.Time
+}
+
+func (k ApiKey) HasScope(scope ApiKeyScope) bool {
+	return slices.Contains(k.Scopes, scope) || slices.Contains(k.Scopes, ApiKeyScopeAdmin)
+}
+
+type ApiKeyStorage interface {
+	Create(ctx context.Context, key ApiKey) (int64, error)
+	Get(ctx context.Context, id int64) (ApiKey, error)
+	GetByHash(ctx context.Context, hash string) (ApiKey, error)
+	List(ctx context.Context) ([]ApiKey, error)
+	Update(ctx context.Context, key ApiKey) error
+}
+
+type ApiKeyRepository struct {
+	storage ApiKeyStorage
+
+	mu    sync.Mutex
+	cache map[string]cachedApiKey
+}
+
+type cachedApiKey struct {
+	key       ApiKey
+	fetchedAt time.Time
+}
+
+func NewApiKeyRepository(storage ApiKeyStorage) *ApiKeyRepository {
+	return &ApiKeyRepository{storage: storage, cache: make(map[string]cachedApiKey)}
+}
+
+func (repo *ApiKeyRepository) Create(ctx context.Context, name string, scopes []ApiKeyScope, expiresAt *time.Time) (ApiKey, string, error) {
+	secret, err := newApiKeySecret()
+	if err != nil {
+		return ApiKey{}, "", fmt.Errorf("failed to create api key %q: %w", name, err)
+	}
+
+	key := ApiKey{
+		Name:      name,
+		Prefix:    secret[:apiKeyDisplayChars],
+		Hash:      HashApiKey(secret),
+		Scopes:    scopes,
+		CreatedAt: time.Now().UTC(),
+		ExpiresAt: expiresAt,
+	}
+
+	id, err := repo.storage.Create(ctx, key)
+	if err != nil {
+		return ApiKey{}, "", fmt.Errorf("failed to create api key %q: %w", name, err)
+	}
+
+	key.ID = id
+
+	return key, secret, nil
+}
+
+func (repo *ApiKeyRepository) List(ctx context.Context) ([]ApiKey, error) {
+	keys, err := repo.storage.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+func (repo *ApiKeyRepository) Rotate(ctx context.Context, id int64) (ApiKey, string, error) {
+	key, err := repo.active(ctx, id)
+	if err != nil {
+		return ApiKey{}, "", err
+	}
+
+	secret, err := newApiKeySecret()
+	if err != nil {
+		return ApiKey{}, "", fmt.Errorf("failed to rotate api key %d: %w", id, err)
+	}
+
+	previousHash := key.Hash
+	key.Prefix = secret[:apiKeyDisplayChars]
+	key.Hash = HashApiKey(secret)
+
+	err = repo.storage.Update(ctx, key)
+	if err != nil {
+		return ApiKey{}, "", fmt.Errorf("failed to rotate api key %d: %w", id, err)
+	}
+
+	repo.forget(previousHash)
+
+	return key, secret, nil
+}
+
+func (repo *ApiKeyRepository) Revoke(ctx context.Context, id int64) (ApiKey, error) {
+	key, err := repo.active(ctx, id)
+	if err != nil {
+		return ApiKey{}, err
+	}
+
+	revokedAt := time.Now().UTC()
+	key.RevokedAt = &revokedAt
+
+	err = repo.storage.Update(ctx, key)
+	if err != nil {
+		return ApiKey{}, fmt.Errorf("failed to revoke api key %d: %w", id, err)
+	}
+
+	repo.forget(key.Hash)
+
+	return key, nil
+}
+
+func (repo *ApiKeyRepository) Authenticate(ctx context.Context, secret string, scope ApiKeyScope) (ApiKey, error) {
+	key, err := repo.byHash(ctx, HashApiKey(secret))
+	if err != nil {
+		return ApiKey{}, fmt.Errorf("failed to authenticate api key: %w", err)
+	}
+
+	err = key.usable(time.Now())
+	if err != nil {
+		return ApiKey{}, fmt.Errorf("failed to authenticate api key %d: %w", key.ID, err)
+	}
+
+	if !key.HasScope(scope) {
+		return ApiKey{}, fmt.Errorf("api key %d cannot be used for %s: %w", key.ID, scope, ErrApiKeyMissingScope)
+	}
+
+	return key, nil
+}
+
+func (repo *ApiKeyRepository) byHash(ctx context.Context, hash string) (ApiKey, error) {
+	now := time.Now()
+
+	repo.mu.Lock()
+	cached, ok := repo.cache[hash]
+	repo.mu.Unlock()
+
+	if ok && now.Sub(cached.fetchedAt) < apiKeyCacheTTL {
+		return cached.key, nil
+	}
+
+	key, err := repo.storage.GetByHash(ctx, hash)
+	if err != nil {
+		repo.forget(hash)
+		return ApiKey{}, err
+	}
+
+	repo.mu.Lock()
+	repo.cache[hash] = cachedApiKey{key: key, fetchedAt: now}
+	repo.mu.Unlock()
+
+	return key, nil
+}
+
+func (repo *ApiKeyRepository) forget(hash string) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	delete(repo.cache, hash)
+}
+
+func (repo *ApiKeyRepository) active(ctx context.Context, id int64) (ApiKey, error) {
+	key, err := repo.storage.Get(ctx, id)
+	if err != nil {
+		return ApiKey{}, fmt.Errorf("failed to get api key %d: %w", id, err)
+	}
+
+	if key.RevokedAt != nil {
+		repo.forget(key.Hash)
+		return ApiKey{}, fmt.Errorf("api key %d: %w", id, ErrApiKeyRevoked)
+	}
+
+	return key, nil
+}
+
+func (k ApiKey) usable(now time.Time) error {
+	if k.RevokedAt != nil {
+		return ErrApiKeyRevoked
+	}
+
+	if k.ExpiresAt != nil && !now.Before(*k.ExpiresAt) {
+		return ErrApiKeyExpired
+	}
+
+	return nil
+}
+
+func HashApiKey(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:])
+}
+
+func newApiKeySecret() (string, error) {
+	b := make([]byte, apiKeySecretBytes)
+
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return apiKeyPrefix + hex.EncodeToString(b), nil
+}
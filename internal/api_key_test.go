@@ -0,0 +1,137 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fedorov-dmitry/go-test-api/internal"
+	"github.com/fedorov-dmitry/go-test-api/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestApiKeyRepository_CreateAndAuthenticate(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockApiKeyStorage(ctrl)
+	repo := internal.NewApiKeyRepository(mockStorage)
+
+	ctx := context.Background()
+
+	var stored internal.ApiKey
+	mockStorage.
+		EXPECT().
+		Create(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, key internal.ApiKey) (int64, error) {
+			stored = key
+			return 3, nil
+		})
+
+	key, secret, err := repo.Create(ctx, "billing", []internal.ApiKeyScope{internal.ApiKeyScopeReadRates}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if key.ID != 3 || !strings.HasPrefix(secret, key.Prefix) || stored.Hash != internal.HashApiKey(secret) || strings.Contains(stored.Hash, secret) {
+		t.Fatalf("unexpected key %+v for secret %q", stored, secret)
+	}
+
+	stored.ID = 3
+	// the second lookup is served from the cache
+	mockStorage.EXPECT().GetByHash(ctx, stored.Hash).Return(stored, nil)
+
+	if _, err := repo.Authenticate(ctx, secret, internal.ApiKeyScopeReadRates); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := repo.Authenticate(ctx, secret, internal.ApiKeyScopeSync); !errors.Is(err, internal.ErrApiKeyMissingScope) {
+		t.Fatalf("expected ErrApiKeyMissingScope, got %v", err)
+	}
+
+	expiresAt := time.Now().Add(-time.Minute)
+	expired := stored
+	expired.ExpiresAt = &expiresAt
+	mockStorage.EXPECT().GetByHash(ctx, stored.Hash).Return(expired, nil)
+
+	if _, err := internal.NewApiKeyRepository(mockStorage).Authenticate(ctx, secret, internal.ApiKeyScopeReadRates); !errors.Is(err, internal.ErrApiKeyExpired) {
+		t.Fatalf("expected ErrApiKeyExpired, got %v", err)
+	}
+}
+
+func TestApiKeyRepository_RotateAndRevoke(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockApiKeyStorage(ctrl)
+	repo := internal.NewApiKeyRepository(mockStorage)
+
+	ctx := context.Background()
+	key := internal.ApiKey{ID: 3, Name: "billing", Prefix: "gta_00000000", Hash: internal.HashApiKey("gta_old"), Scopes: []internal.ApiKeyScope{internal.ApiKeyScopeAdmin}}
+
+	mockStorage.EXPECT().Get(ctx, int64(3)).Return(key, nil)
+	mockStorage.
+		EXPECT().
+		Update(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, rotated internal.ApiKey) error {
+			if rotated.ID != 3 || rotated.Hash == key.Hash || rotated.RevokedAt != nil {
+				t.Fatalf("unexpected rotated key: %+v", rotated)
+			}
+			return nil
+		})
+
+	rotated, secret, err := repo.Rotate(ctx, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rotated.Hash != internal.HashApiKey(secret) || !rotated.HasScope(internal.ApiKeyScopeSync) {
+		t.Fatalf("unexpected rotated key: %+v", rotated)
+	}
+
+	revokedAt := time.Now()
+	revoked := rotated
+	revoked.RevokedAt = &revokedAt
+	mockStorage.EXPECT().Get(ctx, int64(3)).Return(revoked, nil)
+
+	if _, err := repo.Revoke(ctx, 3); !errors.Is(err, internal.ErrApiKeyRevoked) {
+		t.Fatalf("expected ErrApiKeyRevoked, got %v", err)
+	}
+}
+
+func TestApiKeyRepository_RevokeInvalidatesCachedKey(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockApiKeyStorage(ctrl)
+	repo := internal.NewApiKeyRepository(mockStorage)
+
+	ctx := context.Background()
+	key := internal.ApiKey{ID: 3, Name: "billing", Hash: internal.HashApiKey("gta_secret"), Scopes: []internal.ApiKeyScope{internal.ApiKeyScopeReadRates}}
+
+	mockStorage.EXPECT().GetByHash(ctx, key.Hash).Return(key, nil)
+
+	if _, err := repo.Authenticate(ctx, "gta_secret", internal.ApiKeyScopeReadRates); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mockStorage.EXPECT().Get(ctx, int64(3)).Return(key, nil)
+	mockStorage.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+
+	revoked, err := repo.Revoke(ctx, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mockStorage.EXPECT().GetByHash(ctx, key.Hash).Return(revoked, nil)
+
+	if _, err := repo.Authenticate(ctx, "gta_secret", internal.ApiKeyScopeReadRates); !errors.Is(err, internal.ErrApiKeyRevoked) {
+		t.Fatalf("expected ErrApiKeyRevoked, got %v", err)
+	}
+}
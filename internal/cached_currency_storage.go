@@ -0,0 +1,222 @@
+package internal
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+type CacheStats struct {
+	Entries       int
+	Capacity      int
+	Hits          int64
+	Misses        int64
+	Evictions     int64
+	Invalidations int64
+}
+
+type CachedCurrencyStorage struct {
+	CurrencyStorage
+
+	capacity int
+	todayTTL time.Duration
+	pastTTL  time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+	bases   map[Currency]map[string]struct{}
+	version uint64
+	stats   CacheStats
+}
+
+type cacheEntry struct {
+	key       string
+	scope     cacheScope
+	value     any
+	err       error
+	expiresAt time.Time
+}
+
+type cacheScope struct {
+	base     Currency
+	currency Currency
+	from     string
+	to       string
+}
+
+func NewCachedCurrencyStorage(storage CurrencyStorage, capacity int, todayTTL time.Duration, pastTTL time.Duration) *CachedCurrencyStorage {
+	return &CachedCurrencyStorage{
+		CurrencyStorage: storage,
+		capacity:        capacity,
+		todayTTL:        todayTTL,
+		pastTTL:         pastTTL,
+		entries:         make(map[string]*list.Element),
+		lru:             list.New(),
+		bases:           make(map[Currency]map[string]struct{}),
+		stats:           CacheStats{Capacity: capacity},
+	}
+}
+
+func (c *CachedCurrencyStorage) Get(ctx context.Context, baseCurrency Currency, currency Currency, date time.Time) (CurrencyRate, error) {
+	scope := cacheScope{base: baseCurrency, currency: currency, from: dayKey(date), to: dayKey(date)}
+
+	return cached(c, ctx, "get", scope, func() (CurrencyRate, error) {
+		return c.CurrencyStorage.Get(ctx, baseCurrency, currency, date)
+	})
+}
+
+func (c *CachedCurrencyStorage) GetLatest(ctx context.Context, baseCurrency Currency, currency Currency, date time.Time) (CurrencyRate, error) {
+	scope := cacheScope{base: baseCurrency, currency: currency, to: dayKey(date)}
+
+	return cached(c, ctx, "latest", scope, func() (CurrencyRate, error) {
+		return c.CurrencyStorage.GetLatest(ctx, baseCurrency, currency, date)
+	})
+}
+
+func (c *CachedCurrencyStorage) GetMany(ctx context.Context, baseCurrency Currency, date time.Time) ([]CurrencyRate, error) {
+	scope := cacheScope{base: baseCurrency, from: dayKey(date), to: dayKey(date)}
+
+	rates, err := cached(c, ctx, "many", scope, func() ([]CurrencyRate, error) {
+		return c.CurrencyStorage.GetMany(ctx, baseCurrency, date)
+	})
+
+	return slices.Clone(rates), err
+}
+
+func (c *CachedCurrencyStorage) GetRange(ctx context.Context, baseCurrency Currency, currency Currency, from time.Time, to time.Time) ([]CurrencyRate, error) {
+	scope := cacheScope{base: baseCurrency, currency: currency, from: dayKey(from), to: dayKey(to)}
+
+	rates, err := cached(c, ctx, "range", scope, func() ([]CurrencyRate, error) {
+		return c.CurrencyStorage.GetRange(ctx, baseCurrency, currency, from, to)
+	})
+
+	return slices.Clone(rates), err
+}
+
+func (c *CachedCurrencyStorage) Set(ctx context.Context, rate CurrencyRate) error {
+	defer c.Invalidate([]CurrencyRate{rate})
+
+	return c.CurrencyStorage.Set(ctx, rate)
+}
+
+func (c *CachedCurrencyStorage) SetMany(ctx context.Context, rates []CurrencyRate) error {
+	defer c.Invalidate(rates)
+
+	return c.CurrencyStorage.SetMany(ctx, rates)
+}
+
+func (c *CachedCurrencyStorage) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Entries = c.lru.Len()
+
+	return stats
+}
+
+func cached[T any](c *CachedCurrencyStorage, ctx context.Context, method string, scope cacheScope, load func() (T, error)) (T, error) {
+	if c.capacity <= 0 {
+		return load()
+	}
+
+	asKnownAt := ""
+	if t, ok := AsKnownAt(ctx); ok {
+		asKnownAt = t.UTC().Format(time.RFC3339Nano)
+	}
+	key := fmt.Sprintf("%s|%s|%s|%s|%s|%s", method, scope.base, scope.currency, scope.from, scope.to, asKnownAt)
+
+	c.mu.Lock()
+	if element, ok := c.entries[key]; ok {
+		entry := element.Value.(*cacheEntry)
+		if time.Now().Before(entry.expiresAt) {
+			c.lru.MoveToFront(element)
+			c.stats.Hits++
+			c.mu.Unlock()
+
+			value, _ := entry.value.(T)
+			return value, entry.err
+		}
+
+		c.remove(element)
+	}
+	c.stats.Misses++
+	version := c.version
+	c.mu.Unlock()
+
+	value, err := load()
+	if err != nil && !errors.Is(err, ErrRateNotFound) {
+		return value, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.version != version {
+		return value, err
+	}
+
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+
+	ttl := c.pastTTL
+	if scope.to >= dayKey(time.Now().UTC()) {
+		ttl = c.todayTTL
+	}
+
+	c.entries[key] = c.lru.PushFront(&cacheEntry{key: key, scope: scope, value: value, err: err, expiresAt: time.Now().Add(ttl)})
+	if c.bases[scope.base] == nil {
+		c.bases[scope.base] = make(map[string]struct{})
+	}
+	c.bases[scope.base][key] = struct{}{}
+
+	for c.lru.Len() > c.capacity {
+		c.remove(c.lru.Back())
+		c.stats.Evictions++
+	}
+
+	return value, err
+}
+
+func (c *CachedCurrencyStorage) Invalidate(rates []CurrencyRate) {
+	if len(rates) == 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.version++
+
+	for _, rate := range rates {
+		date := dayKey(rate.Date)
+
+		for key := range c.bases[rate.Base] {
+			element := c.entries[key]
+			scope := element.Value.(*cacheEntry).scope
+
+			if (scope.currency == "" || scope.currency == rate.Currency) && scope.from <= date && date <= scope.to {
+				c.remove(element)
+				c.stats.Invalidations++
+			}
+		}
+	}
+}
+
+func (c *CachedCurrencyStorage) remove(element *list.Element) {
+	entry := element.Value.(*cacheEntry)
+
+	c.lru.Remove(element)
+	delete(c.entries, entry.key)
+	delete(c.bases[entry.scope.base], entry.key)
+}
+
+func dayKey(date time.Time) string {
+	return date.Format("2006-01-02")
+}
@@ -0,0 +1,205 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fedorov-dmitry/go-test-api/internal"
+	"github.com/fedorov-dmitry/go-test-api/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCachedCurrencyStorage_ServesRepeatedReadsFromCache(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockCurrencyStorage(ctrl)
+	cache := internal.NewCachedCurrencyStorage(mockStorage, 100, time.Minute, time.Hour)
+
+	ctx := context.Background()
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+	jpy := internal.NewCurrency("jpy")
+	date := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)
+
+	mockStorage.
+		EXPECT().
+		Get(ctx, usd, eur, date).
+		Return(internal.CurrencyRate{Date: date, Base: usd, Currency: eur, Rate: dec("0.92")}, nil)
+	mockStorage.
+		EXPECT().
+		Get(ctx, usd, jpy, date).
+		Return(internal.CurrencyRate{}, internal.ErrRateNotFound)
+	mockStorage.
+		EXPECT().
+		Get(internal.WithAsKnownAt(ctx, date), usd, eur, date).
+		Return(internal.CurrencyRate{}, internal.ErrRateNotFound)
+
+	for range 3 {
+		got, err := cache.Get(ctx, usd, eur, date)
+		if err != nil || !got.Rate.Equal(dec("0.92")) {
+			t.Fatalf("got %+v, %v", got, err)
+		}
+
+		_, err = cache.Get(ctx, usd, jpy, date)
+		if !errors.Is(err, internal.ErrRateNotFound) {
+			t.Fatalf("expected ErrRateNotFound, got %v", err)
+		}
+
+		_, err = cache.Get(internal.WithAsKnownAt(ctx, date), usd, eur, date)
+		if !errors.Is(err, internal.ErrRateNotFound) {
+			t.Fatalf("expected ErrRateNotFound as known at %s, got %v", date, err)
+		}
+	}
+
+	stats := cache.Stats()
+	if stats.Hits != 6 || stats.Misses != 3 || stats.Entries != 3 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestCachedCurrencyStorage_DoesNotCacheErrors(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockCurrencyStorage(ctrl)
+	cache := internal.NewCachedCurrencyStorage(mockStorage, 100, time.Minute, time.Hour)
+
+	ctx := context.Background()
+	usd := internal.NewCurrency("usd")
+	date := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)
+
+	mockStorage.
+		EXPECT().
+		GetMany(ctx, usd, date).
+		Return(nil, errors.New("connection refused")).
+		Times(2)
+
+	for range 2 {
+		if _, err := cache.GetMany(ctx, usd, date); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	}
+}
+
+func TestCachedCurrencyStorage_InvalidatesOnWrite(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockCurrencyStorage(ctrl)
+	cache := internal.NewCachedCurrencyStorage(mockStorage, 100, time.Minute, time.Hour)
+
+	ctx := context.Background()
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+	jpy := internal.NewCurrency("jpy")
+	day := func(d int) time.Time { return time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC) }
+
+	mockStorage.EXPECT().GetLatest(ctx, usd, eur, day(14)).Return(internal.CurrencyRate{Date: day(12), Rate: dec("0.91")}, nil)
+	mockStorage.EXPECT().GetRange(ctx, usd, eur, day(1), day(10)).Return([]internal.CurrencyRate{{Date: day(10), Rate: dec("0.9")}}, nil)
+	mockStorage.EXPECT().GetMany(ctx, usd, day(13)).Return([]internal.CurrencyRate{}, nil)
+	mockStorage.EXPECT().Get(ctx, usd, jpy, day(13)).Return(internal.CurrencyRate{}, internal.ErrRateNotFound)
+
+	read := func() {
+		if _, err := cache.GetLatest(ctx, usd, eur, day(14)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := cache.GetRange(ctx, usd, eur, day(1), day(10)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := cache.GetMany(ctx, usd, day(13)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		_, _ = cache.Get(ctx, usd, jpy, day(13))
+	}
+
+	read()
+
+	// A usd-eur rate for the 13th invalidates the latest usd-eur rate on the 14th and all usd rates on the 13th,
+	// but neither the usd-eur range that ends on the 10th nor the usd-jpy rate.
+	rate := internal.CurrencyRate{Date: day(13), Base: usd, Currency: eur, Rate: dec("0.92")}
+	mockStorage.EXPECT().SetMany(ctx, []internal.CurrencyRate{rate}).Return(nil)
+	mockStorage.EXPECT().GetLatest(ctx, usd, eur, day(14)).Return(rate, nil)
+	mockStorage.EXPECT().GetMany(ctx, usd, day(13)).Return([]internal.CurrencyRate{rate}, nil)
+
+	if err := cache.SetMany(ctx, []internal.CurrencyRate{rate}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	read()
+
+	got, err := cache.GetLatest(ctx, usd, eur, day(14))
+	if err != nil || !got.Rate.Equal(dec("0.92")) {
+		t.Fatalf("got %+v, %v, want the newly written rate", got, err)
+	}
+
+	if stats := cache.Stats(); stats.Invalidations != 2 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestCachedCurrencyStorage_EvictsLeastRecentlyUsed(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockCurrencyStorage(ctrl)
+	cache := internal.NewCachedCurrencyStorage(mockStorage, 2, time.Minute, time.Hour)
+
+	ctx := context.Background()
+	usd := internal.NewCurrency("usd")
+	day := func(d int) time.Time { return time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC) }
+
+	mockStorage.EXPECT().GetMany(ctx, usd, day(1)).Return(nil, nil)
+	mockStorage.EXPECT().GetMany(ctx, usd, day(2)).Return(nil, nil).Times(2)
+	mockStorage.EXPECT().GetMany(ctx, usd, day(3)).Return(nil, nil)
+
+	for _, d := range []int{1, 2, 1, 3, 1, 2} {
+		if _, err := cache.GetMany(ctx, usd, day(d)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if stats := cache.Stats(); stats.Entries != 2 || stats.Evictions != 2 || stats.Hits != 2 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestCachedCurrencyStorage_ExpiresTodaysRates(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockCurrencyStorage(ctrl)
+	cache := internal.NewCachedCurrencyStorage(mockStorage, 100, 20*time.Millisecond, time.Hour)
+
+	ctx := context.Background()
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+	today := time.Now().UTC()
+	past := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)
+
+	mockStorage.EXPECT().GetLatest(ctx, usd, eur, today).Return(internal.CurrencyRate{Rate: dec("0.92")}, nil).Times(2)
+	mockStorage.EXPECT().GetLatest(ctx, usd, eur, past).Return(internal.CurrencyRate{Rate: dec("0.91")}, nil)
+
+	for range 2 {
+		if _, err := cache.GetLatest(ctx, usd, eur, today); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := cache.GetLatest(ctx, usd, eur, past); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		time.Sleep(30 * time.Millisecond)
+	}
+}
@@ -0,0 +1,81 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrIncompleteRates = errors.New("currency rates are incomplete")
+
+type CompositeCurrencyRateSource struct {
+	sources []CurrencyRateSource
+}
+
+func NewCompositeCurrencyRateSource(sources ...CurrencyRateSource) *CompositeCurrencyRateSource {
+	return &CompositeCurrencyRateSource{sources: sources}
+}
+
+func (s *CompositeCurrencyRateSource) Get(ctx context.Context, baseCurrency Currency, currencies []Currency, date time.Time) ([]CurrencyRate, error) {
+	found := make(map[Currency]CurrencyRate, len(currencies))
+	missing := currencies
+	errs := make([]error, 0)
+
+	for i, source := range s.sources {
+		if len(missing) == 0 {
+			break
+		}
+
+		rates, err := source.Get(ctx, baseCurrency, missing, date)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("source #%d: %w", i+1, err))
+			continue
+		}
+
+		for _, rate := range rates {
+			if _, ok := found[rate.Currency]; !ok && containsCurrency(missing, rate.Currency) {
+				found[rate.Currency] = rate
+			}
+		}
+
+		missing = make([]Currency, 0, len(missing))
+		for _, currency := range currencies {
+			if _, ok := found[currency]; !ok {
+				missing = append(missing, currency)
+			}
+		}
+	}
+
+	if len(found) == 0 && len(errs) > 0 {
+		return nil, fmt.Errorf("all currency rate sources failed for %s for %s: %w", baseCurrency, date.Format("2006-01-02"), errors.Join(errs...))
+	}
+
+	result := make([]CurrencyRate, 0, len(found))
+	for _, currency := range currencies {
+		if rate, ok := found[currency]; ok {
+			result = append(result, rate)
+		}
+	}
+
+	if len(missing) > 0 && len(errs) > 0 {
+		return result, fmt.Errorf("%w: got %d of %d currencies for %s for %s: %w",
+			ErrIncompleteRates, len(found), len(currencies), baseCurrency, date.Format("2006-01-02"), errors.Join(errs...))
+	}
+
+	return result, nil
+}
+
+func isPartial(err error, rates []CurrencyRate) bool {
+	return len(rates) > 0 && errors.Is(err, ErrIncompleteRates)
+}
+
+func containsCurrency(currencies []Currency, currency Currency) bool {
+	for _, c := range currencies {
+		if c == currency {
+			return true
+		}
+	}
+
+	return false
+}
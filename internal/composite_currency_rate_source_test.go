@@ -0,0 +1,136 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fedorov-dmitry/go-test-api/internal"
+	"github.com/fedorov-dmitry/go-test-api/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCompositeCurrencyRateSource_FallsBackOnError(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	primary := mocks.NewMockCurrencyRateSource(ctrl)
+	secondary := mocks.NewMockCurrencyRateSource(ctrl)
+	source := internal.NewCompositeCurrencyRateSource(primary, secondary)
+
+	date := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+
+	primary.
+		EXPECT().
+		Get(gomock.Any(), usd, []internal.Currency{eur}, date).
+		Return(nil, errors.New("cdn down"))
+	secondary.
+		EXPECT().
+		Get(gomock.Any(), usd, []internal.Currency{eur}, date).
+		Return([]internal.CurrencyRate{{Date: date, Base: usd, Currency: eur, Rate: dec("0.92"), Provider: "secondary"}}, nil)
+
+	rates, err := source.Get(context.Background(), usd, []internal.Currency{eur}, date)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rates) != 1 || rates[0].Provider != "secondary" || !rates[0].Rate.Equal(dec("0.92")) {
+		t.Fatalf("unexpected rates: %+v", rates)
+	}
+}
+
+func TestCompositeCurrencyRateSource_FallsBackOnMissingCurrencies(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	primary := mocks.NewMockCurrencyRateSource(ctrl)
+	secondary := mocks.NewMockCurrencyRateSource(ctrl)
+	source := internal.NewCompositeCurrencyRateSource(primary, secondary)
+
+	date := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+	jpy := internal.NewCurrency("jpy")
+
+	primary.
+		EXPECT().
+		Get(gomock.Any(), usd, []internal.Currency{eur, jpy}, date).
+		Return([]internal.CurrencyRate{{Date: date, Base: usd, Currency: jpy, Rate: dec("145.1"), Provider: "primary"}}, nil)
+	secondary.
+		EXPECT().
+		Get(gomock.Any(), usd, []internal.Currency{eur}, date).
+		Return([]internal.CurrencyRate{{Date: date, Base: usd, Currency: eur, Rate: dec("0.92"), Provider: "secondary"}}, nil)
+
+	rates, err := source.Get(context.Background(), usd, []internal.Currency{eur, jpy}, date)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rates) != 2 {
+		t.Fatalf("expected 2 rates, got %+v", rates)
+	}
+	if rates[0].Currency != eur || rates[0].Provider != "secondary" {
+		t.Fatalf("unexpected first rate: %+v", rates[0])
+	}
+	if rates[1].Currency != jpy || rates[1].Provider != "primary" {
+		t.Fatalf("unexpected second rate: %+v", rates[1])
+	}
+}
+
+func TestCompositeCurrencyRateSource_AllSourcesFail(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	primary := mocks.NewMockCurrencyRateSource(ctrl)
+	secondary := mocks.NewMockCurrencyRateSource(ctrl)
+	source := internal.NewCompositeCurrencyRateSource(primary, secondary)
+
+	primary.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("cdn down"))
+	secondary.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("mirror down"))
+
+	_, err := source.Get(context.Background(), internal.NewCurrency("usd"), []internal.Currency{internal.NewCurrency("eur")}, time.Now())
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestCompositeCurrencyRateSource_ReportsErrorsWithPartialRates(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	primary := mocks.NewMockCurrencyRateSource(ctrl)
+	secondary := mocks.NewMockCurrencyRateSource(ctrl)
+	source := internal.NewCompositeCurrencyRateSource(primary, secondary)
+
+	date := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+	jpy := internal.NewCurrency("jpy")
+
+	cdnDown := errors.New("cdn down")
+	primary.
+		EXPECT().
+		Get(gomock.Any(), usd, []internal.Currency{eur, jpy}, date).
+		Return(nil, cdnDown)
+	secondary.
+		EXPECT().
+		Get(gomock.Any(), usd, []internal.Currency{eur, jpy}, date).
+		Return([]internal.CurrencyRate{{Date: date, Base: usd, Currency: eur, Rate: dec("0.92"), Provider: "secondary"}}, nil)
+
+	rates, err := source.Get(context.Background(), usd, []internal.Currency{eur, jpy}, date)
+	if !errors.Is(err, internal.ErrIncompleteRates) || !errors.Is(err, cdnDown) {
+		t.Fatalf("expected ErrIncompleteRates wrapping the primary error, got %v", err)
+	}
+	if len(rates) != 1 || rates[0].Currency != eur {
+		t.Fatalf("expected the partial result, got %+v", rates)
+	}
+}
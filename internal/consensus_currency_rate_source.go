@@ -0,0 +1,131 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type ConsensusCurrencyRateSource struct {
+	sources   []CurrencyRateSource
+	tolerance float64
+}
+
+func NewConsensusCurrencyRateSource(tolerance float64, sources ...CurrencyRateSource) *ConsensusCurrencyRateSource {
+	return &ConsensusCurrencyRateSource{sources: sources, tolerance: tolerance}
+}
+
+func (s *ConsensusCurrencyRateSource) Get(ctx context.Context, baseCurrency Currency, currencies []Currency, date time.Time) ([]CurrencyRate, error) {
+	results := make([][]CurrencyRate, len(s.sources))
+	errs := make([]error, len(s.sources))
+
+	var wg sync.WaitGroup
+	for i, source := range s.sources {
+		wg.Go(func() {
+			rates, err := source.Get(ctx, baseCurrency, currencies, date)
+			if err != nil {
+				errs[i] = fmt.Errorf("source #%d: %w", i+1, err)
+				return
+			}
+			results[i] = rates
+		})
+	}
+	wg.Wait()
+
+	quotes := make(map[Currency][]CurrencyRate, len(currencies))
+	for _, rates := range results {
+		for _, rate := range rates {
+			if rate.Rate.IsPositive() && containsCurrency(currencies, rate.Currency) {
+				quotes[rate.Currency] = append(quotes[rate.Currency], rate)
+			}
+		}
+	}
+
+	if len(quotes) == 0 {
+		if err := errors.Join(errs...); err != nil {
+			return nil, fmt.Errorf("all currency rate sources failed for %s for %s: %w", baseCurrency, date.Format("2006-01-02"), err)
+		}
+	}
+
+	result := make([]CurrencyRate, 0, len(quotes))
+	for _, currency := range currencies {
+		if _, ok := quotes[currency]; !ok {
+			continue
+		}
+
+		rate, ok := s.agree(baseCurrency, currency, date, quotes[currency])
+		if !ok {
+			log.Printf("no consensus for %s-%s for %s: sources deviate more than %.2f%% from the median",
+				baseCurrency, currency, date.Format("2006-01-02"), s.tolerance*100)
+			continue
+		}
+
+		result = append(result, rate)
+	}
+
+	return result, nil
+}
+
+func (s *ConsensusCurrencyRateSource) agree(baseCurrency Currency, currency Currency, date time.Time, quotes []CurrencyRate) (CurrencyRate, bool) {
+	median := medianRate(quotes)
+
+	accepted := make([]CurrencyRate, 0, len(quotes))
+	for _, quote := range quotes {
+		if quote.Rate.Div(median).Sub(decimal.NewFromInt(1)).Abs().GreaterThan(decimal.NewFromFloat(s.tolerance)) {
+			log.Printf("rejecting %s-%s for %s from %s: %v is more than %.2f%% away from the median %v",
+				baseCurrency, currency, date.Format("2006-01-02"), quote.Provider, quote.Rate, s.tolerance*100, median)
+			continue
+		}
+		accepted = append(accepted, quote)
+	}
+
+	if len(accepted) == 0 {
+		return CurrencyRate{}, false
+	}
+
+	agreed := medianRate(accepted)
+
+	low, high := accepted[0].Rate, accepted[0].Rate
+	providers := make([]string, 0, len(accepted))
+	derived := false
+	for _, quote := range accepted {
+		low = decimal.Min(low, quote.Rate)
+		high = decimal.Max(high, quote.Rate)
+		derived = derived || quote.Derived
+		if quote.Provider != "" && !slices.Contains(providers, quote.Provider) {
+			providers = append(providers, quote.Provider)
+		}
+	}
+
+	return CurrencyRate{
+		Date:     date,
+		Base:     baseCurrency,
+		Currency: currency,
+		Rate:     agreed,
+		Derived:  derived,
+		Provider: strings.Join(providers, ","),
+		Spread:   high.Sub(low).Div(agreed),
+	}, true
+}
+
+func medianRate(rates []CurrencyRate) decimal.Decimal {
+	values := make([]decimal.Decimal, len(rates))
+	for i, rate := range rates {
+		values[i] = rate.Rate
+	}
+	slices.SortFunc(values, decimal.Decimal.Cmp)
+
+	middle := len(values) / 2
+	if len(values)%2 == 0 {
+		return values[middle-1].Add(values[middle]).Div(decimal.NewFromInt(2))
+	}
+
+	return values[middle]
+}
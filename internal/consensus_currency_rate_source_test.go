@@ -0,0 +1,135 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fedorov-dmitry/go-test-api/internal"
+	"github.com/fedorov-dmitry/go-test-api/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestConsensusCurrencyRateSource_RejectsOutliers(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	first := mocks.NewMockCurrencyRateSource(ctrl)
+	second := mocks.NewMockCurrencyRateSource(ctrl)
+	third := mocks.NewMockCurrencyRateSource(ctrl)
+	source := internal.NewConsensusCurrencyRateSource(0.01, first, second, third)
+
+	date := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+	jpy := internal.NewCurrency("jpy")
+	currencies := []internal.Currency{eur, jpy}
+
+	first.
+		EXPECT().
+		Get(gomock.Any(), usd, currencies, date).
+		Return([]internal.CurrencyRate{
+			{Base: usd, Currency: eur, Rate: dec("0.92"), Provider: "first"},
+			{Base: usd, Currency: jpy, Rate: dec("145"), Provider: "first"},
+		}, nil)
+	second.
+		EXPECT().
+		Get(gomock.Any(), usd, currencies, date).
+		Return([]internal.CurrencyRate{
+			{Base: usd, Currency: eur, Rate: dec("0.93"), Provider: "second"},
+			{Base: usd, Currency: jpy, Rate: dec("160"), Provider: "second"},
+		}, nil)
+	third.
+		EXPECT().
+		Get(gomock.Any(), usd, currencies, date).
+		Return([]internal.CurrencyRate{
+			{Base: usd, Currency: eur, Rate: dec("0.925"), Provider: "third"},
+			{Base: usd, Currency: jpy, Rate: dec("145.5"), Provider: "third"},
+		}, nil)
+
+	rates, err := source.Get(context.Background(), usd, currencies, date)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rates) != 2 {
+		t.Fatalf("got %d rates, want 2", len(rates))
+	}
+
+	if rates[0].Currency != eur || !rates[0].Rate.Equal(dec("0.925")) || rates[0].Provider != "first,second,third" {
+		t.Fatalf("unexpected eur rate: %+v", rates[0])
+	}
+	if !rates[0].Spread.Equal(dec("0.01").Div(dec("0.925"))) {
+		t.Fatalf("eur Spread=%v", rates[0].Spread)
+	}
+
+	if rates[1].Currency != jpy || !rates[1].Rate.Equal(dec("145.25")) || rates[1].Provider != "first,third" {
+		t.Fatalf("unexpected jpy rate: %+v", rates[1])
+	}
+	if !rates[1].Spread.Equal(dec("0.5").Div(dec("145.25"))) {
+		t.Fatalf("jpy Spread=%v", rates[1].Spread)
+	}
+}
+
+func TestConsensusCurrencyRateSource_IgnoresFailingSource(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	first := mocks.NewMockCurrencyRateSource(ctrl)
+	second := mocks.NewMockCurrencyRateSource(ctrl)
+	source := internal.NewConsensusCurrencyRateSource(0.01, first, second)
+
+	date := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+
+	first.
+		EXPECT().
+		Get(gomock.Any(), usd, []internal.Currency{eur}, date).
+		Return(nil, errors.New("cdn down"))
+	second.
+		EXPECT().
+		Get(gomock.Any(), usd, []internal.Currency{eur}, date).
+		Return([]internal.CurrencyRate{{Base: usd, Currency: eur, Rate: dec("0.92"), Provider: "second"}}, nil)
+
+	rates, err := source.Get(context.Background(), usd, []internal.Currency{eur}, date)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rates) != 1 || !rates[0].Rate.Equal(dec("0.92")) || rates[0].Provider != "second" || !rates[0].Spread.Equal(dec("0")) {
+		t.Fatalf("unexpected rates: %+v", rates)
+	}
+}
+
+func TestConsensusCurrencyRateSource_AllSourcesFail(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	first := mocks.NewMockCurrencyRateSource(ctrl)
+	second := mocks.NewMockCurrencyRateSource(ctrl)
+	source := internal.NewConsensusCurrencyRateSource(0.01, first, second)
+
+	date := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+
+	first.
+		EXPECT().
+		Get(gomock.Any(), usd, []internal.Currency{eur}, date).
+		Return(nil, internal.ErrRateNotFound)
+	second.
+		EXPECT().
+		Get(gomock.Any(), usd, []internal.Currency{eur}, date).
+		Return(nil, errors.New("timeout"))
+
+	_, err := source.Get(context.Background(), usd, []internal.Currency{eur}, date)
+	if !errors.Is(err, internal.ErrRateNotFound) {
+		t.Fatalf("expected wrapped ErrRateNotFound, got %v", err)
+	}
+}
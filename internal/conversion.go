@@ -0,0 +1,51 @@
+package internal
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const defaultMinorUnits = 2
+
+var minorUnits = func() map[Currency]int {
+	units := make(map[Currency]int, len(defaultCurrencies))
+	for _, currency := range defaultCurrencies {
+		units[currency.Code] = currency.MinorUnits
+	}
+
+	return units
+}()
+
+func (c Currency) MinorUnits() int {
+	units, ok := minorUnits[c]
+	if !ok {
+		return defaultMinorUnits
+	}
+
+	return units
+}
+
+type Conversion struct {
+	From   Currency
+	To     Currency
+	Amount decimal.Decimal
+	Result decimal.Decimal
+	Rate   decimal.Decimal
+	Date   time.Time
+}
+
+func NewConversion(rate CurrencyRate, amount decimal.Decimal, minorUnits int) Conversion {
+	return Conversion{
+		From:   rate.Base,
+		To:     rate.Currency,
+		Amount: amount,
+		Result: RoundToMinorUnits(amount.Mul(rate.Rate), minorUnits),
+		Rate:   rate.Rate,
+		Date:   rate.Date,
+	}
+}
+
+func RoundToMinorUnits(amount decimal.Decimal, minorUnits int) decimal.Decimal {
+	return amount.Round(int32(minorUnits))
+}
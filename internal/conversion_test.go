@@ -0,0 +1,66 @@
+package internal_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fedorov-dmitry/go-test-api/internal"
+	"github.com/shopspring/decimal"
+)
+
+func TestNewConversion_RoundsToMinorUnits(t *testing.T) {
+	t.Parallel()
+
+	date := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		rate   internal.CurrencyRate
+		amount decimal.Decimal
+		want   decimal.Decimal
+	}{
+		{
+			name:   "two minor units",
+			rate:   internal.CurrencyRate{Date: date, Base: "usd", Currency: "eur", Rate: dec("0.923456")},
+			amount: dec("100"),
+			want:   dec("92.35"),
+		},
+		{
+			name:   "zero minor units",
+			rate:   internal.CurrencyRate{Date: date, Base: "usd", Currency: "jpy", Rate: dec("145.67")},
+			amount: dec("10"),
+			want:   dec("1457"),
+		},
+		{
+			name:   "three minor units",
+			rate:   internal.CurrencyRate{Date: date, Base: "usd", Currency: "kwd", Rate: dec("0.30789")},
+			amount: dec("10"),
+			want:   dec("3.079"),
+		},
+		{
+			name:   "negative amount rounds away from zero",
+			rate:   internal.CurrencyRate{Date: date, Base: "usd", Currency: "eur", Rate: dec("0.5")},
+			amount: dec("-0.25"),
+			want:   dec("-0.13"),
+		},
+		{
+			name:   "half a minor unit is not lost to binary rounding",
+			rate:   internal.CurrencyRate{Date: date, Base: "usd", Currency: "eur", Rate: dec("1")},
+			amount: dec("1.005"),
+			want:   dec("1.01"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := internal.NewConversion(tt.rate, tt.amount, tt.rate.Currency.MinorUnits())
+
+			if !got.Result.Equal(tt.want) {
+				t.Fatalf("Result=%v, want %v", got.Result, tt.want)
+			}
+			if got.From != tt.rate.Base || got.To != tt.rate.Currency || !got.Rate.Equal(tt.rate.Rate) || !got.Date.Equal(date) || !got.Amount.Equal(tt.amount) {
+				t.Fatalf("unexpected conversion: %+v", got)
+			}
+		})
+	}
+}
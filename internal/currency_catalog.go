@@ -0,0 +1,120 @@
+package internal
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+var (
+	ErrUnknownCurrency  = errors.New("unknown currency")
+	ErrInactiveCurrency = errors.New("inactive currency")
+)
+
+type CurrencyKind string
+
+const (
+	CurrencyKindFiat   CurrencyKind = "fiat"
+	CurrencyKindCrypto CurrencyKind = "crypto"
+)
+
+type CurrencyInfo struct {
+	Code        Currency
+	NumericCode string
+	Name        string
+	MinorUnits  int
+	Active      bool
+	Kind        CurrencyKind
+}
+
+type CurrencyCatalogStorage interface {
+	List(ctx context.Context) ([]CurrencyInfo, error)
+	Seed(ctx context.Context, currencies []CurrencyInfo) error
+}
+
+type CurrencyCatalog struct {
+	storage CurrencyCatalogStorage
+
+	mu         sync.RWMutex
+	currencies map[Currency]CurrencyInfo
+}
+
+func NewCurrencyCatalog(storage CurrencyCatalogStorage) *CurrencyCatalog {
+	return &CurrencyCatalog{storage: storage, currencies: make(map[Currency]CurrencyInfo)}
+}
+
+func (c *CurrencyCatalog) Load(ctx context.Context) error {
+	err := c.storage.Seed(ctx, defaultCurrencies)
+	if err != nil {
+		return fmt.Errorf("failed to seed currency catalog: %w", err)
+	}
+
+	return c.Reload(ctx)
+}
+
+func (c *CurrencyCatalog) Reload(ctx context.Context) error {
+	currencies, err := c.storage.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load currency catalog: %w", err)
+	}
+
+	byCode := make(map[Currency]CurrencyInfo, len(currencies))
+	for _, currency := range currencies {
+		byCode[currency.Code] = currency
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.currencies = byCode
+
+	return nil
+}
+
+func (c *CurrencyCatalog) List() []CurrencyInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	currencies := make([]CurrencyInfo, 0, len(c.currencies))
+	for _, currency := range c.currencies {
+		currencies = append(currencies, currency)
+	}
+
+	slices.SortFunc(currencies, func(a, b CurrencyInfo) int {
+		return cmp.Compare(a.Code, b.Code)
+	})
+
+	return currencies
+}
+
+func (c *CurrencyCatalog) Get(currency Currency) (CurrencyInfo, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	info, ok := c.currencies[currency]
+	if !ok {
+		return CurrencyInfo{}, fmt.Errorf("%w: %q", ErrUnknownCurrency, currency)
+	}
+
+	return info, nil
+}
+
+func (c *CurrencyCatalog) Validate(currencies ...Currency) error {
+	errs := make([]error, 0)
+	for _, currency := range currencies {
+		info, err := c.Get(currency)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if !info.Active {
+			errs = append(errs, fmt.Errorf("%w: %q", ErrInactiveCurrency, currency))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,173 @@
+package internal
+
+var defaultCurrencies = []CurrencyInfo{
+	{Code: "aed", NumericCode: "784", Name: "UAE Dirham", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "afn", NumericCode: "971", Name: "Afghani", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "all", NumericCode: "008", Name: "Lek", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "amd", NumericCode: "051", Name: "Armenian Dram", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "ang", NumericCode: "532", Name: "Netherlands Antillean Guilder", MinorUnits: 2, Active: false, Kind: CurrencyKindFiat},
+	{Code: "aoa", NumericCode: "973", Name: "Kwanza", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "ars", NumericCode: "032", Name: "Argentine Peso", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "aud", NumericCode: "036", Name: "Australian Dollar", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "awg", NumericCode: "533", Name: "Aruban Florin", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "azn", NumericCode: "944", Name: "Azerbaijan Manat", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "bam", NumericCode: "977", Name: "Convertible Mark", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "bbd", NumericCode: "052", Name: "Barbados Dollar", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "bdt", NumericCode: "050", Name: "Taka", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "bgn", NumericCode: "975", Name: "Bulgarian Lev", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "bhd", NumericCode: "048", Name: "Bahraini Dinar", MinorUnits: 3, Active: true, Kind: CurrencyKindFiat},
+	{Code: "bif", NumericCode: "108", Name: "Burundi Franc", MinorUnits: 0, Active: true, Kind: CurrencyKindFiat},
+	{Code: "bmd", NumericCode: "060", Name: "Bermudian Dollar", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "bnd", NumericCode: "096", Name: "Brunei Dollar", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "bob", NumericCode: "068", Name: "Boliviano", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "brl", NumericCode: "986", Name: "Brazilian Real", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "bsd", NumericCode: "044", Name: "Bahamian Dollar", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "btn", NumericCode: "064", Name: "Ngultrum", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "bwp", NumericCode: "072", Name: "Pula", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "byn", NumericCode: "933", Name: "Belarusian Ruble", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "bzd", NumericCode: "084", Name: "Belize Dollar", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "cad", NumericCode: "124", Name: "Canadian Dollar", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "cdf", NumericCode: "976", Name: "Congolese Franc", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "chf", NumericCode: "756", Name: "Swiss Franc", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "clp", NumericCode: "152", Name: "Chilean Peso", MinorUnits: 0, Active: true, Kind: CurrencyKindFiat},
+	{Code: "cny", NumericCode: "156", Name: "Yuan Renminbi", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "cop", NumericCode: "170", Name: "Colombian Peso", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "crc", NumericCode: "188", Name: "Costa Rican Colon", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "cup", NumericCode: "192", Name: "Cuban Peso", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "cve", NumericCode: "132", Name: "Cabo Verde Escudo", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "czk", NumericCode: "203", Name: "Czech Koruna", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "djf", NumericCode: "262", Name: "Djibouti Franc", MinorUnits: 0, Active: true, Kind: CurrencyKindFiat},
+	{Code: "dkk", NumericCode: "208", Name: "Danish Krone", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "dop", NumericCode: "214", Name: "Dominican Peso", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "dzd", NumericCode: "012", Name: "Algerian Dinar", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "egp", NumericCode: "818", Name: "Egyptian Pound", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "ern", NumericCode: "232", Name: "Nakfa", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "etb", NumericCode: "230", Name: "Ethiopian Birr", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "eur", NumericCode: "978", Name: "Euro", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "fjd", NumericCode: "242", Name: "Fiji Dollar", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "fkp", NumericCode: "238", Name: "Falkland Islands Pound", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "gbp", NumericCode: "826", Name: "Pound Sterling", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "gel", NumericCode: "981", Name: "Lari", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "ghs", NumericCode: "936", Name: "Ghana Cedi", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "gip", NumericCode: "292", Name: "Gibraltar Pound", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "gmd", NumericCode: "270", Name: "Dalasi", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "gnf", NumericCode: "324", Name: "Guinean Franc", MinorUnits: 0, Active: true, Kind: CurrencyKindFiat},
+	{Code: "gtq", NumericCode: "320", Name: "Quetzal", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "gyd", NumericCode: "328", Name: "Guyana Dollar", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "hkd", NumericCode: "344", Name: "Hong Kong Dollar", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "hnl", NumericCode: "340", Name: "Lempira", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "hrk", NumericCode: "191", Name: "Kuna", MinorUnits: 2, Active: false, Kind: CurrencyKindFiat},
+	{Code: "htg", NumericCode: "332", Name: "Gourde", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "huf", NumericCode: "348", Name: "Forint", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "idr", NumericCode: "360", Name: "Rupiah", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "ils", NumericCode: "376", Name: "New Israeli Sheqel", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "inr", NumericCode: "356", Name: "Indian Rupee", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "iqd", NumericCode: "368", Name: "Iraqi Dinar", MinorUnits: 3, Active: true, Kind: CurrencyKindFiat},
+	{Code: "irr", NumericCode: "364", Name: "Iranian Rial", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "isk", NumericCode: "352", Name: "Iceland Krona", MinorUnits: 0, Active: true, Kind: CurrencyKindFiat},
+	{Code: "jmd", NumericCode: "388", Name: "Jamaican Dollar", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "jod", NumericCode: "400", Name: "Jordanian Dinar", MinorUnits: 3, Active: true, Kind: CurrencyKindFiat},
+	{Code: "jpy", NumericCode: "392", Name: "Yen", MinorUnits: 0, Active: true, Kind: CurrencyKindFiat},
+	{Code: "kes", NumericCode: "404", Name: "Kenyan Shilling", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "kgs", NumericCode: "417", Name: "Som", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "khr", NumericCode: "116", Name: "Riel", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "kmf", NumericCode: "174", Name: "Comorian Franc", MinorUnits: 0, Active: true, Kind: CurrencyKindFiat},
+	{Code: "kpw", NumericCode: "408", Name: "North Korean Won", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "krw", NumericCode: "410", Name: "Won", MinorUnits: 0, Active: true, Kind: CurrencyKindFiat},
+	{Code: "kwd", NumericCode: "414", Name: "Kuwaiti Dinar", MinorUnits: 3, Active: true, Kind: CurrencyKindFiat},
+	{Code: "kyd", NumericCode: "136", Name: "Cayman Islands Dollar", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "kzt", NumericCode: "398", Name: "Tenge", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "lak", NumericCode: "418", Name: "Lao Kip", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "lbp", NumericCode: "422", Name: "Lebanese Pound", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "lkr", NumericCode: "144", Name: "Sri Lanka Rupee", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "lrd", NumericCode: "430", Name: "Liberian Dollar", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "lsl", NumericCode: "426", Name: "Loti", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "lyd", NumericCode: "434", Name: "Libyan Dinar", MinorUnits: 3, Active: true, Kind: CurrencyKindFiat},
+	{Code: "mad", NumericCode: "504", Name: "Moroccan Dirham", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "mdl", NumericCode: "498", Name: "Moldovan Leu", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "mga", NumericCode: "969", Name: "Malagasy Ariary", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "mkd", NumericCode: "807", Name: "Denar", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "mmk", NumericCode: "104", Name: "Kyat", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "mnt", NumericCode: "496", Name: "Tugrik", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "mop", NumericCode: "446", Name: "Pataca", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "mru", NumericCode: "929", Name: "Ouguiya", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "mur", NumericCode: "480", Name: "Mauritius Rupee", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "mvr", NumericCode: "462", Name: "Rufiyaa", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "mwk", NumericCode: "454", Name: "Malawi Kwacha", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "mxn", NumericCode: "484", Name: "Mexican Peso", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "myr", NumericCode: "458", Name: "Malaysian Ringgit", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "mzn", NumericCode: "943", Name: "Mozambique Metical", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "nad", NumericCode: "516", Name: "Namibia Dollar", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "ngn", NumericCode: "566", Name: "Naira", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "nio", NumericCode: "558", Name: "Cordoba Oro", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "nok", NumericCode: "578", Name: "Norwegian Krone", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "npr", NumericCode: "524", Name: "Nepalese Rupee", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "nzd", NumericCode: "554", Name: "New Zealand Dollar", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "omr", NumericCode: "512", Name: "Rial Omani", MinorUnits: 3, Active: true, Kind: CurrencyKindFiat},
+	{Code: "pab", NumericCode: "590", Name: "Balboa", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "pen", NumericCode: "604", Name: "Sol", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "pgk", NumericCode: "598", Name: "Kina", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "php", NumericCode: "608", Name: "Philippine Peso", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "pkr", NumericCode: "586", Name: "Pakistan Rupee", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "pln", NumericCode: "985", Name: "Zloty", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "pyg", NumericCode: "600", Name: "Guarani", MinorUnits: 0, Active: true, Kind: CurrencyKindFiat},
+	{Code: "qar", NumericCode: "634", Name: "Qatari Rial", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "ron", NumericCode: "946", Name: "Romanian Leu", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "rsd", NumericCode: "941", Name: "Serbian Dinar", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "rub", NumericCode: "643", Name: "Russian Ruble", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "rwf", NumericCode: "646", Name: "Rwanda Franc", MinorUnits: 0, Active: true, Kind: CurrencyKindFiat},
+	{Code: "sar", NumericCode: "682", Name: "Saudi Riyal", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "sbd", NumericCode: "090", Name: "Solomon Islands Dollar", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "scr", NumericCode: "690", Name: "Seychelles Rupee", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "sdg", NumericCode: "938", Name: "Sudanese Pound", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "sek", NumericCode: "752", Name: "Swedish Krona", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "sgd", NumericCode: "702", Name: "Singapore Dollar", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "shp", NumericCode: "654", Name: "Saint Helena Pound", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "sle", NumericCode: "925", Name: "Leone", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "sll", NumericCode: "694", Name: "Leone (old)", MinorUnits: 2, Active: false, Kind: CurrencyKindFiat},
+	{Code: "sos", NumericCode: "706", Name: "Somali Shilling", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "srd", NumericCode: "968", Name: "Surinam Dollar", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "ssp", NumericCode: "728", Name: "South Sudanese Pound", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "stn", NumericCode: "930", Name: "Dobra", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "svc", NumericCode: "222", Name: "El Salvador Colon", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "syp", NumericCode: "760", Name: "Syrian Pound", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "szl", NumericCode: "748", Name: "Lilangeni", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "thb", NumericCode: "764", Name: "Baht", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "tjs", NumericCode: "972", Name: "Somoni", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "tmt", NumericCode: "934", Name: "Turkmenistan New Manat", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "tnd", NumericCode: "788", Name: "Tunisian Dinar", MinorUnits: 3, Active: true, Kind: CurrencyKindFiat},
+	{Code: "top", NumericCode: "776", Name: "Pa'anga", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "try", NumericCode: "949", Name: "Turkish Lira", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "ttd", NumericCode: "780", Name: "Trinidad and Tobago Dollar", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "twd", NumericCode: "901", Name: "New Taiwan Dollar", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "tzs", NumericCode: "834", Name: "Tanzanian Shilling", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "uah", NumericCode: "980", Name: "Hryvnia", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "ugx", NumericCode: "800", Name: "Uganda Shilling", MinorUnits: 0, Active: true, Kind: CurrencyKindFiat},
+	{Code: "usd", NumericCode: "840", Name: "US Dollar", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "uyu", NumericCode: "858", Name: "Peso Uruguayo", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "uzs", NumericCode: "860", Name: "Uzbekistan Sum", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "ves", NumericCode: "928", Name: "Bolivar Soberano", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "vnd", NumericCode: "704", Name: "Dong", MinorUnits: 0, Active: true, Kind: CurrencyKindFiat},
+	{Code: "vuv", NumericCode: "548", Name: "Vatu", MinorUnits: 0, Active: true, Kind: CurrencyKindFiat},
+	{Code: "wst", NumericCode: "882", Name: "Tala", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "xaf", NumericCode: "950", Name: "CFA Franc BEAC", MinorUnits: 0, Active: true, Kind: CurrencyKindFiat},
+	{Code: "xcd", NumericCode: "951", Name: "East Caribbean Dollar", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "xcg", NumericCode: "532", Name: "Caribbean Guilder", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "xof", NumericCode: "952", Name: "CFA Franc BCEAO", MinorUnits: 0, Active: true, Kind: CurrencyKindFiat},
+	{Code: "xpf", NumericCode: "953", Name: "CFP Franc", MinorUnits: 0, Active: true, Kind: CurrencyKindFiat},
+	{Code: "yer", NumericCode: "886", Name: "Yemeni Rial", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "zar", NumericCode: "710", Name: "Rand", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "zmw", NumericCode: "967", Name: "Zambian Kwacha", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "zwg", NumericCode: "924", Name: "Zimbabwe Gold", MinorUnits: 2, Active: true, Kind: CurrencyKindFiat},
+	{Code: "zwl", NumericCode: "932", Name: "Zimbabwe Dollar", MinorUnits: 2, Active: false, Kind: CurrencyKindFiat},
+	{Code: "ada", NumericCode: "", Name: "Cardano", MinorUnits: 6, Active: true, Kind: CurrencyKindCrypto},
+	{Code: "bnb", NumericCode: "", Name: "BNB", MinorUnits: 8, Active: true, Kind: CurrencyKindCrypto},
+	{Code: "btc", NumericCode: "", Name: "Bitcoin", MinorUnits: 8, Active: true, Kind: CurrencyKindCrypto},
+	{Code: "doge", NumericCode: "", Name: "Dogecoin", MinorUnits: 8, Active: true, Kind: CurrencyKindCrypto},
+	{Code: "eth", NumericCode: "", Name: "Ether", MinorUnits: 8, Active: true, Kind: CurrencyKindCrypto},
+	{Code: "ltc", NumericCode: "", Name: "Litecoin", MinorUnits: 8, Active: true, Kind: CurrencyKindCrypto},
+	{Code: "sol", NumericCode: "", Name: "Solana", MinorUnits: 9, Active: true, Kind: CurrencyKindCrypto},
+	{Code: "usdc", NumericCode: "", Name: "USD Coin", MinorUnits: 6, Active: true, Kind: CurrencyKindCrypto},
+	{Code: "usdt", NumericCode: "", Name: "Tether", MinorUnits: 6, Active: true, Kind: CurrencyKindCrypto},
+	{Code: "xrp", NumericCode: "", Name: "XRP", MinorUnits: 6, Active: true, Kind: CurrencyKindCrypto},
+}
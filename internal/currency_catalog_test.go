@@ -0,0 +1,135 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fedorov-dmitry/go-test-api/internal"
+	"github.com/fedorov-dmitry/go-test-api/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCurrencyCatalog_LoadAndValidate(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockCurrencyCatalogStorage(ctrl)
+	catalog := internal.NewCurrencyCatalog(mockStorage)
+
+	ctx := context.Background()
+
+	mockStorage.
+		EXPECT().
+		Seed(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, currencies []internal.CurrencyInfo) error {
+			if len(currencies) < 150 {
+				t.Fatalf("seeded %d currencies, want the ISO 4217 list", len(currencies))
+			}
+			return nil
+		})
+	mockStorage.
+		EXPECT().
+		List(ctx).
+		Return([]internal.CurrencyInfo{
+			{Code: "usd", NumericCode: "840", Name: "US Dollar", MinorUnits: 2, Active: true, Kind: internal.CurrencyKindFiat},
+			{Code: "btc", Name: "Bitcoin", MinorUnits: 8, Active: true, Kind: internal.CurrencyKindCrypto},
+			{Code: "hrk", NumericCode: "191", Name: "Kuna", MinorUnits: 2, Kind: internal.CurrencyKindFiat},
+		}, nil)
+
+	if err := catalog.Load(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	currencies := catalog.List()
+	if len(currencies) != 3 || currencies[0].Code != "btc" || currencies[2].Code != "usd" {
+		t.Fatalf("unexpected currencies: %+v", currencies)
+	}
+
+	info, err := catalog.Get("btc")
+	if err != nil || info.MinorUnits != 8 {
+		t.Fatalf("got %+v, %v", info, err)
+	}
+
+	if err := catalog.Validate("usd", "btc"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = catalog.Validate("usd", "usdd", "hrk")
+	if !errors.Is(err, internal.ErrUnknownCurrency) || !errors.Is(err, internal.ErrInactiveCurrency) {
+		t.Fatalf("expected unknown and inactive currency errors, got %v", err)
+	}
+}
+
+func TestCurrencyCatalog_LoadError(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockCurrencyCatalogStorage(ctrl)
+	catalog := internal.NewCurrencyCatalog(mockStorage)
+
+	ctx := context.Background()
+
+	mockStorage.EXPECT().Seed(ctx, gomock.Any()).Return(errors.New("relation \"app.currencies\" does not exist"))
+
+	if err := catalog.Load(ctx); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	if _, err := catalog.Get("usd"); !errors.Is(err, internal.ErrUnknownCurrency) {
+		t.Fatalf("expected ErrUnknownCurrency, got %v", err)
+	}
+}
+
+func TestCurrencyCatalog_ReloadPicksUpChanges(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockCurrencyCatalogStorage(ctrl)
+	catalog := internal.NewCurrencyCatalog(mockStorage)
+
+	ctx := context.Background()
+
+	gomock.InOrder(
+		mockStorage.
+			EXPECT().
+			List(ctx).
+			Return([]internal.CurrencyInfo{{Code: "hrk", MinorUnits: 2, Active: true}}, nil),
+		mockStorage.
+			EXPECT().
+			List(ctx).
+			Return([]internal.CurrencyInfo{{Code: "hrk", MinorUnits: 2}}, nil),
+		mockStorage.
+			EXPECT().
+			List(ctx).
+			Return(nil, errors.New("connection refused")),
+	)
+
+	if err := catalog.Reload(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := catalog.Validate("hrk"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := catalog.Reload(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := catalog.Validate("hrk"); !errors.Is(err, internal.ErrInactiveCurrency) {
+		t.Fatalf("expected ErrInactiveCurrency, got %v", err)
+	}
+
+	// a failed reload keeps the previous catalog
+	if err := catalog.Reload(ctx); err == nil {
+		t.Fatal("expected an error")
+	}
+	if _, err := catalog.Get("hrk"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
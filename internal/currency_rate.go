@@ -2,11 +2,16 @@ package internal
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
+var ErrRateNotFound = errors.New("currency rate not found")
+
 type Currency string
 
 func NewCurrency(str string) Currency {
@@ -17,29 +22,61 @@ type CurrencyRate struct {
 	Date     time.Time
 	Base     Currency
 	Currency Currency
-	Rate     float64
+	Rate     decimal.Decimal
+	Derived  bool
+	Provider string
+	Spread   decimal.Decimal
+}
+
+func (rate CurrencyRate) Equal(other CurrencyRate) bool {
+	return rate.Date.Equal(other.Date) &&
+		rate.Base == other.Base &&
+		rate.Currency == other.Currency &&
+		rate.Rate.Equal(other.Rate) &&
+		rate.Derived == other.Derived &&
+		rate.Provider == other.Provider &&
+		rate.Spread.Equal(other.Spread)
+}
+
+type CurrencyRateKey struct {
+	Date     time.Time
+	Base     Currency
+	Currency Currency
 }
 
 type CurrencyStorage interface {
 	Get(ctx context.Context, baseCurrency Currency, currency Currency, date time.Time) (CurrencyRate, error)
 	GetMany(ctx context.Context, baseCurrency Currency, date time.Time) ([]CurrencyRate, error)
+	GetRange(ctx context.Context, baseCurrency Currency, currency Currency, from time.Time, to time.Time) ([]CurrencyRate, error)
+	GetLatest(ctx context.Context, baseCurrency Currency, currency Currency, date time.Time) (CurrencyRate, error)
+	GetLatestMany(ctx context.Context, baseCurrency Currency, from time.Time, to time.Time) ([]CurrencyRate, error)
+	GetKeys(ctx context.Context, from time.Time, to time.Time) ([]CurrencyRateKey, error)
+	GetRevisions(ctx context.Context, baseCurrency Currency, currency Currency, date time.Time) ([]CurrencyRateRevision, error)
 	Set(ctx context.Context, currency CurrencyRate) error
+	SetMany(ctx context.Context, rates []CurrencyRate) error
 }
 
 type CurrencyRateSource interface {
-	Get(baseCurrency Currency, currencies []Currency, date time.Time) ([]CurrencyRate, error)
+	Get(ctx context.Context, baseCurrency Currency, currencies []Currency, date time.Time) ([]CurrencyRate, error)
 }
 
 type CurrencyRepository struct {
 	storage CurrencyStorage
+	pivots  []Currency
 }
 
-func NewCurrencyRepository(storage CurrencyStorage) *CurrencyRepository {
-	return &CurrencyRepository{storage: storage}
+type rateInvalidator interface {
+	Invalidate(rates []CurrencyRate)
+}
+
+type rateLookup func(ctx context.Context, baseCurrency Currency, currency Currency, date time.Time) (CurrencyRate, error)
+
+func NewCurrencyRepository(storage CurrencyStorage, pivots ...Currency) *CurrencyRepository {
+	return &CurrencyRepository{storage: storage, pivots: pivots}
 }
 
 func (repo *CurrencyRepository) Get(ctx context.Context, baseCurrency Currency, currency Currency, date time.Time) (CurrencyRate, error) {
-	rate, err := repo.storage.Get(ctx, baseCurrency, currency, date)
+	rate, err := repo.resolve(ctx, repo.storage.Get, baseCurrency, currency, date)
 	if err != nil {
 		return CurrencyRate{}, fmt.Errorf("failed to get currency rates for %v: %w", baseCurrency, err)
 	}
@@ -47,6 +84,123 @@ func (repo *CurrencyRepository) Get(ctx context.Context, baseCurrency Currency,
 	return rate, nil
 }
 
+func (repo *CurrencyRepository) GetLatest(ctx context.Context, baseCurrency Currency, currency Currency, date time.Time, maxStalenessDays int) (CurrencyRate, error) {
+	requestedDay, _ := time.Parse("2006-01-02", date.Format("2006-01-02"))
+
+	lookup := func(ctx context.Context, baseCurrency Currency, currency Currency, date time.Time) (CurrencyRate, error) {
+		rate, err := repo.storage.GetLatest(ctx, baseCurrency, currency, date)
+		if err != nil {
+			return CurrencyRate{}, err
+		}
+
+		staleDays := int(requestedDay.Sub(rate.Date).Hours() / 24)
+		if staleDays > maxStalenessDays {
+			return CurrencyRate{}, fmt.Errorf("latest currency rate for %s-%s is from %s, more than %d days before %s: %w",
+				baseCurrency, currency, rate.Date.Format("2006-01-02"), maxStalenessDays, requestedDay.Format("2006-01-02"), ErrRateNotFound)
+		}
+
+		return rate, nil
+	}
+
+	rate, err := repo.resolve(ctx, lookup, baseCurrency, currency, date)
+	if err != nil {
+		return CurrencyRate{}, fmt.Errorf("failed to get latest currency rate for %s-%s: %w", baseCurrency, currency, err)
+	}
+
+	return rate, nil
+}
+
+func (repo *CurrencyRepository) GetPrevious(ctx context.Context, baseCurrency Currency, date time.Time, days int) (map[Currency]CurrencyRate, error) {
+	rates, err := repo.storage.GetLatestMany(ctx, baseCurrency, date.AddDate(0, 0, -days), date.AddDate(0, 0, -1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s rates of the %d days before %s: %w", baseCurrency, days, date.Format("2006-01-02"), err)
+	}
+
+	previous := make(map[Currency]CurrencyRate, len(rates))
+	for _, rate := range rates {
+		previous[rate.Currency] = rate
+	}
+
+	return previous, nil
+}
+
+func (repo *CurrencyRepository) resolve(ctx context.Context, lookup rateLookup, baseCurrency Currency, currency Currency, date time.Time) (CurrencyRate, error) {
+	rate, err := repo.resolveDirectOrInverse(ctx, lookup, baseCurrency, currency, date)
+	if !errors.Is(err, ErrRateNotFound) {
+		return rate, err
+	}
+
+	for _, pivot := range repo.pivots {
+		if pivot == baseCurrency || pivot == currency {
+			continue
+		}
+
+		toPivot, err := repo.resolveDirectOrInverse(ctx, lookup, baseCurrency, pivot, date)
+		if errors.Is(err, ErrRateNotFound) {
+			continue
+		}
+		if err != nil {
+			return CurrencyRate{}, err
+		}
+
+		fromPivot, err := repo.resolveDirectOrInverse(ctx, lookup, pivot, currency, date)
+		if errors.Is(err, ErrRateNotFound) {
+			continue
+		}
+		if err != nil {
+			return CurrencyRate{}, err
+		}
+
+		derivedDate := toPivot.Date
+		if fromPivot.Date.Before(derivedDate) {
+			derivedDate = fromPivot.Date
+		}
+
+		provider := toPivot.Provider
+		if fromPivot.Provider != provider {
+			provider = toPivot.Provider + "," + fromPivot.Provider
+		}
+
+		return CurrencyRate{
+			Date:     derivedDate,
+			Base:     baseCurrency,
+			Currency: currency,
+			Rate:     toPivot.Rate.Mul(fromPivot.Rate),
+			Derived:  true,
+			Provider: provider,
+			Spread:   toPivot.Spread.Add(fromPivot.Spread),
+		}, nil
+	}
+
+	return CurrencyRate{}, fmt.Errorf("no direct, inverse or cross rate for %s-%s: %w", baseCurrency, currency, ErrRateNotFound)
+}
+
+func (repo *CurrencyRepository) resolveDirectOrInverse(ctx context.Context, lookup rateLookup, baseCurrency Currency, currency Currency, date time.Time) (CurrencyRate, error) {
+	rate, err := lookup(ctx, baseCurrency, currency, date)
+	if !errors.Is(err, ErrRateNotFound) {
+		return rate, err
+	}
+
+	inverse, err := lookup(ctx, currency, baseCurrency, date)
+	if err != nil {
+		return CurrencyRate{}, err
+	}
+
+	if inverse.Rate.IsZero() {
+		return CurrencyRate{}, fmt.Errorf("zero rate for %s-%s: %w", currency, baseCurrency, ErrRateNotFound)
+	}
+
+	return CurrencyRate{
+		Date:     inverse.Date,
+		Base:     baseCurrency,
+		Currency: currency,
+		Rate:     decimal.NewFromInt(1).Div(inverse.Rate),
+		Derived:  true,
+		Provider: inverse.Provider,
+		Spread:   inverse.Spread,
+	}, nil
+}
+
 func (repo *CurrencyRepository) GetMany(ctx context.Context, baseCurrency Currency, date time.Time) ([]CurrencyRate, error) {
 	currencies, err := repo.storage.GetMany(ctx, baseCurrency, date)
 	if err != nil {
@@ -56,7 +210,38 @@ func (repo *CurrencyRepository) GetMany(ctx context.Context, baseCurrency Curren
 	return currencies, nil
 }
 
-func (repo *CurrencyRepository) Create(ctx context.Context, date time.Time, baseCurrency Currency, currency Currency, rate float64) (CurrencyRate, error) {
+func (repo *CurrencyRepository) GetRange(ctx context.Context, baseCurrency Currency, currency Currency, from time.Time, to time.Time) ([]CurrencyRate, error) {
+	if to.Before(from) {
+		return nil, fmt.Errorf("invalid date range: %s is after %s", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	}
+
+	rates, err := repo.storage.GetRange(ctx, baseCurrency, currency, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get currency rates for %s-%s: %w", baseCurrency, currency, err)
+	}
+
+	return rates, nil
+}
+
+func (repo *CurrencyRepository) GetKeys(ctx context.Context, from time.Time, to time.Time) ([]CurrencyRateKey, error) {
+	keys, err := repo.storage.GetKeys(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stored currency rates between %s and %s: %w", from.Format("2006-01-02"), to.Format("2006-01-02"), err)
+	}
+
+	return keys, nil
+}
+
+func (repo *CurrencyRepository) GetRevisions(ctx context.Context, baseCurrency Currency, currency Currency, date time.Time) ([]CurrencyRateRevision, error) {
+	revisions, err := repo.storage.GetRevisions(ctx, baseCurrency, currency, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revisions of %s-%s for %s: %w", baseCurrency, currency, date.Format("2006-01-02"), err)
+	}
+
+	return revisions, nil
+}
+
+func (repo *CurrencyRepository) Create(ctx context.Context, date time.Time, baseCurrency Currency, currency Currency, rate decimal.Decimal) (CurrencyRate, error) {
 	currencyRate := CurrencyRate{
 		Date:     date,
 		Base:     baseCurrency,
@@ -64,10 +249,38 @@ func (repo *CurrencyRepository) Create(ctx context.Context, date time.Time, base
 		Rate:     rate,
 	}
 
-	err := repo.storage.Set(ctx, currencyRate)
+	err := repo.Save(ctx, currencyRate)
 	if err != nil {
-		return CurrencyRate{}, fmt.Errorf("failed to save currency rate %s-%s: %w", baseCurrency, currency, err)
+		return CurrencyRate{}, err
 	}
 
 	return currencyRate, nil
 }
+
+func (repo *CurrencyRepository) Save(ctx context.Context, rate CurrencyRate) error {
+	err := repo.storage.Set(ctx, rate)
+	if err != nil {
+		return fmt.Errorf("failed to save currency rate %s-%s: %w", rate.Base, rate.Currency, err)
+	}
+
+	return nil
+}
+
+func (repo *CurrencyRepository) Invalidate(rates ...CurrencyRate) {
+	if invalidator, ok := repo.storage.(rateInvalidator); ok {
+		invalidator.Invalidate(rates)
+	}
+}
+
+func (repo *CurrencyRepository) SaveMany(ctx context.Context, rates []CurrencyRate) error {
+	if len(rates) == 0 {
+		return nil
+	}
+
+	err := repo.storage.SetMany(ctx, rates)
+	if err != nil {
+		return fmt.Errorf("failed to save %d currency rates: %w", len(rates), err)
+	}
+
+	return nil
+}
@@ -3,48 +3,436 @@ package internal
 import (
 	"context"
 	"fmt"
+	"log"
+	"sync"
 	"time"
 )
 
 type CurrencySynchronizer struct {
-	repository CurrencyRepository
-	source     CurrencyRateSource
-	currencies []Currency
+	repository    CurrencyRepository
+	source        CurrencyRateSource
+	currencies    []Currency
+	tracked       *TrackedCurrencyRepository
+	retryPolicy   RetryPolicy
+	concurrency   int
+	runs          *SyncRunRepository
+	retention     int
+	finalizeAfter int
+
+	fetchBase          Currency
+	crossRateTolerance float64
+
+	anomalyPolicy AnomalyPolicy
+	quarantine    *QuarantineRepository
+
+	unfillable *unfillableKeys
+}
+
+type SynchronizerOption func(*CurrencySynchronizer)
+
+func WithRetryPolicy(policy RetryPolicy) SynchronizerOption {
+	return func(c *CurrencySynchronizer) {
+		c.retryPolicy = policy
+	}
+}
+
+func WithConcurrency(concurrency int) SynchronizerOption {
+	return func(c *CurrencySynchronizer) {
+		c.concurrency = concurrency
+	}
+}
+
+func WithRunHistory(runs *SyncRunRepository) SynchronizerOption {
+	return func(c *CurrencySynchronizer) {
+		c.runs = runs
+	}
+}
+
+func WithRetentionDays(days int) SynchronizerOption {
+	return func(c *CurrencySynchronizer) {
+		c.retention = days
+	}
+}
+
+func WithFinalizeAfterDays(days int) SynchronizerOption {
+	return func(c *CurrencySynchronizer) {
+		c.finalizeAfter = days
+	}
+}
+
+func WithTrackedCurrencies(tracked *TrackedCurrencyRepository) SynchronizerOption {
+	return func(c *CurrencySynchronizer) {
+		c.tracked = tracked
+	}
+}
+
+func NewCurrencySynchronizer(repository CurrencyRepository, source CurrencyRateSource, currencies []Currency, opts ...SynchronizerOption) *CurrencySynchronizer {
+	c := &CurrencySynchronizer{
+		repository:  repository,
+		source:      source,
+		currencies:  currencies,
+		retryPolicy: NoRetryPolicy(),
+		concurrency: 1,
+		unfillable:  newUnfillableKeys(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
 }
 
-func NewCurrencySynchronizer(repository CurrencyRepository, source CurrencyRateSource, currencies []Currency) *CurrencySynchronizer {
-	return &CurrencySynchronizer{
-		repository: repository,
-		source:     source,
-		currencies: currencies,
+func (c *CurrencySynchronizer) Sync(ctx context.Context, trigger SyncTrigger, days int) error {
+	c, err := c.withTrackedCurrencies(ctx)
+	if err != nil {
+		return err
+	}
+
+	units := c.unitsForTodayAndLastNDays(days)
+
+	if c.finalizeAfter > 0 {
+		units, err = c.IncrementalUnits(ctx, days)
+		if err != nil {
+			log.Printf("failed to plan incremental sync, syncing everything: %v", err)
+			units = c.unitsForTodayAndLastNDays(days)
+		}
+	}
+
+	if len(units) == 0 {
+		return nil
 	}
+
+	return c.Run(ctx, trigger, units).Err()
 }
 
-func (c *CurrencySynchronizer) UpdateCurrencyRatesForTodayAndLastNDays(ctx context.Context, days int) error {
+func (c *CurrencySynchronizer) IncrementalUnits(ctx context.Context, days int) ([]SyncUnit, error) {
+	c, err := c.withTrackedCurrencies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	today, _ := time.Parse("2006-01-02", time.Now().Format("2006-01-02"))
+
+	units := make([]SyncUnit, 0)
+
 	for _, base := range c.currencies {
-		otherCurrencies := make([]Currency, 0, len(c.currencies)-1)
-		for _, cur := range c.currencies {
-			if cur != base {
-				otherCurrencies = append(otherCurrencies, cur)
-			}
+		for i := 0; i <= days && i < c.finalizeAfter; i++ {
+			units = append(units, SyncUnit{Base: base, Date: today.AddDate(0, 0, -i)})
 		}
+	}
 
+	if days < c.finalizeAfter {
+		return units, nil
+	}
+
+	gaps, err := c.detectGaps(ctx, today.AddDate(0, 0, -days), today.AddDate(0, 0, -c.finalizeAfter))
+	if err != nil {
+		return nil, err
+	}
+
+	return append(units, gaps.Units()...), nil
+}
+
+func (c *CurrencySynchronizer) UnitsForTodayAndLastNDays(ctx context.Context, days int) ([]SyncUnit, error) {
+	c, err := c.withTrackedCurrencies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.unitsForTodayAndLastNDays(days), nil
+}
+
+func (c *CurrencySynchronizer) unitsForTodayAndLastNDays(days int) []SyncUnit {
+	today, _ := time.Parse("2006-01-02", time.Now().Format("2006-01-02"))
+
+	units := make([]SyncUnit, 0, len(c.currencies)*(days+1))
+
+	for _, base := range c.currencies {
 		for i := 0; i <= days; i++ {
-			date := time.Now().AddDate(0, 0, -i)
+			units = append(units, SyncUnit{Base: base, Date: today.AddDate(0, 0, -i)})
+		}
+	}
+
+	return units
+}
+
+func (c *CurrencySynchronizer) UnitsForRange(ctx context.Context, from time.Time, to time.Time, bases []Currency) ([]SyncUnit, error) {
+	if to.Before(from) {
+		return nil, fmt.Errorf("invalid date range: %s is after %s", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	}
+
+	c, err := c.withTrackedCurrencies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bases) == 0 {
+		bases = c.currencies
+	}
+
+	for _, base := range bases {
+		if !containsCurrency(c.currencies, base) {
+			return nil, fmt.Errorf("currency %s is not synchronized", base)
+		}
+	}
+
+	units := make([]SyncUnit, 0)
+
+	for _, base := range bases {
+		for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+			units = append(units, SyncUnit{Base: base, Date: date})
+		}
+	}
+
+	return units, nil
+}
+
+func (c *CurrencySynchronizer) UnitsForNewCurrency(ctx context.Context, currency Currency, days int) ([]SyncUnit, error) {
+	c, err := c.withTrackedCurrencies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !containsCurrency(c.currencies, currency) {
+		return nil, fmt.Errorf("currency %s is not synchronized", currency)
+	}
+
+	today, _ := time.Parse("2006-01-02", time.Now().Format("2006-01-02"))
+
+	units := make([]SyncUnit, 0, len(c.currencies)*(days+1))
+
+	for i := 0; i <= days; i++ {
+		date := today.AddDate(0, 0, -i)
+
+		for _, base := range c.currencies {
+			if base == currency {
+				units = append(units, SyncUnit{Base: base, Date: date})
+			} else {
+				units = append(units, SyncUnit{Base: base, Date: date, Currencies: []Currency{currency}})
+			}
+		}
+	}
+
+	return units, nil
+}
 
-			result, err := c.source.Get(base, otherCurrencies, date)
-			if err != nil {
-				return fmt.Errorf("failed to get currency rates for %s for %s: %w", base, date.Format("2006-01-02"), err)
+func (c *CurrencySynchronizer) Run(ctx context.Context, trigger SyncTrigger, units []SyncUnit) SyncReport {
+	return c.RunWithProgress(ctx, trigger, units, nil)
+}
+
+func (c *CurrencySynchronizer) RunWithProgress(ctx context.Context, trigger SyncTrigger, units []SyncUnit, progress func(SyncUnitResult)) SyncReport {
+	c, err := c.withTrackedCurrencies(ctx)
+	if err != nil {
+		report := SyncReport{}
+		for _, unit := range units {
+			result := SyncUnitResult{Unit: unit, Err: err}
+			report.Failed = append(report.Failed, result)
+			if progress != nil {
+				progress(result)
 			}
+		}
+
+		return report
+	}
+
+	units = c.Plan(units)
+
+	var run SyncRun
+	if c.runs != nil {
+		run, err = c.runs.Start(ctx, trigger, units)
+		if err != nil {
+			log.Printf("failed to record sync run: %v", err)
+		}
+	}
+
+	report := c.run(ctx, units, progress)
+
+	if c.runs != nil && run.ID != 0 {
+		_, err := c.runs.Finish(ctx, run, report)
+		if err != nil {
+			log.Printf("failed to record sync run: %v", err)
+		}
+	}
+
+	return report
+}
 
-			for _, rate := range result {
-				_, err := c.repository.Create(ctx, date, base, rate.Currency, rate.Rate)
-				if err != nil {
-					return fmt.Errorf("failed to create currency rate for %s-%s for %s: %w", base, rate.Currency, date, err)
+type fetchedUnit struct {
+	result   SyncUnitResult
+	rates    []CurrencyRate
+	fetchErr error
+}
+
+func (c *CurrencySynchronizer) run(ctx context.Context, units []SyncUnit, progress func(SyncUnitResult)) SyncReport {
+	results := make([]SyncUnitResult, len(units))
+	fetched := make([]fetchedUnit, len(units))
+	indexes := make(chan int)
+
+	var mu sync.Mutex
+	remaining := make(map[string]int)
+	batches := make(map[string][]int)
+	pivots := newPivotFetches()
+	for _, unit := range units {
+		remaining[dayKey(unit.Date)]++
+	}
+
+	workers := max(1, min(c.concurrency, len(units)))
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Go(func() {
+			for i := range indexes {
+				fetched[i] = c.fetchUnit(ctx, units[i], pivots)
+				c.recordUnfillable(fetched[i])
+
+				day := dayKey(units[i].Date)
+
+				mu.Lock()
+				batches[day] = append(batches[day], i)
+				remaining[day]--
+				batch := batches[day]
+				if remaining[day] > 0 {
+					batch = nil
+				} else {
+					delete(batches, day)
+				}
+				mu.Unlock()
+
+				if batch == nil {
+					continue
+				}
+
+				c.saveBatch(ctx, fetched, batch, results)
+
+				if progress != nil {
+					for _, j := range batch {
+						progress(results[j])
+					}
 				}
 			}
+		})
+	}
+
+	for i := range units {
+		indexes <- i
+	}
+	close(indexes)
+
+	wg.Wait()
+
+	report := SyncReport{}
+
+	for _, result := range results {
+		if result.Err != nil {
+			report.Failed = append(report.Failed, result)
+		} else {
+			report.Succeeded = append(report.Succeeded, result)
+		}
+	}
+
+	return report
+}
+
+func (c *CurrencySynchronizer) fetchUnit(ctx context.Context, unit SyncUnit, pivots *pivotFetches) fetchedUnit {
+	if c.fetchBase != "" {
+		return c.fetchCrossRates(ctx, unit, pivots)
+	}
+
+	fetched := fetchedUnit{result: SyncUnitResult{Unit: unit}}
+
+	targets := unit.Currencies
+	if len(targets) == 0 {
+		targets = c.otherCurrencies(unit.Base)
+	}
+
+	var rates []CurrencyRate
+	err := c.retryPolicy.Do(ctx, func() error {
+		var err error
+		rates, err = c.source.Get(ctx, unit.Base, targets, unit.Date)
+		return err
+	})
+	if err != nil && !isPartial(err, rates) {
+		fetched.result.Err = fmt.Errorf("failed to get currency rates for %s for %s: %w", unit.Base, unit.Date.Format("2006-01-02"), err)
+		return fetched
+	}
+	fetched.fetchErr = err
+
+	for i := range rates {
+		rates[i].Date = unit.Date
+		rates[i].Base = unit.Base
+	}
+	fetched.rates = rates
+
+	return fetched
+}
+
+func (c *CurrencySynchronizer) saveBatch(ctx context.Context, fetched []fetchedUnit, batch []int, results []SyncUnitResult) {
+	rates := make([]CurrencyRate, 0)
+	owners := make([]int, 0)
+
+	for _, i := range batch {
+		results[i] = fetched[i].result
+		if results[i].Err != nil {
+			continue
+		}
+
+		for _, rate := range fetched[i].rates {
+			rates = append(rates, rate)
+			owners = append(owners, i)
+		}
+	}
+
+	accepted, err := c.saveAll(ctx, rates)
+
+	for _, i := range batch {
+		if results[i].Err != nil {
+			continue
+		}
+
+		unit := results[i].Unit
+		switch {
+		case err != nil:
+			results[i].Err = fmt.Errorf("failed to save currency rates for %s for %s: %w", unit.Base, unit.Date.Format("2006-01-02"), err)
+		case fetched[i].fetchErr != nil:
+			results[i].Err = fmt.Errorf("failed to get some currency rates for %s for %s: %w", unit.Base, unit.Date.Format("2006-01-02"), fetched[i].fetchErr)
+		}
+	}
+
+	if err != nil {
+		return
+	}
+
+	for j, ok := range accepted {
+		if ok {
+			results[owners[j]].RowsWritten++
+		}
+	}
+}
+
+func (c *CurrencySynchronizer) otherCurrencies(base Currency) []Currency {
+	otherCurrencies := make([]Currency, 0, len(c.currencies))
+	for _, cur := range c.currencies {
+		if cur != base {
+			otherCurrencies = append(otherCurrencies, cur)
 		}
 	}
 
-	return nil
+	return otherCurrencies
+}
+
+func (c *CurrencySynchronizer) withTrackedCurrencies(ctx context.Context) (*CurrencySynchronizer, error) {
+	if c.tracked == nil {
+		return c, nil
+	}
+
+	currencies, err := c.tracked.Currencies(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the currencies to synchronize: %w", err)
+	}
+
+	current := *c
+	current.currencies = currencies
+	current.tracked = nil
+
+	return &current, nil
 }
@@ -3,6 +3,7 @@ package internal_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -11,7 +12,7 @@ import (
 	"go.uber.org/mock/gomock"
 )
 
-func TestCurrencySynchronizer_UpdateCurrencyRatesForToday_Success(t *testing.T) {
+func TestCurrencySynchronizer_Sync_Success(t *testing.T) {
 	t.Parallel()
 
 	ctrl := gomock.NewController(t)
@@ -33,35 +34,34 @@ func TestCurrencySynchronizer_UpdateCurrencyRatesForToday_Success(t *testing.T)
 	// Expect 2 calls to source.Get (base=usd with [eur], base=eur with [usd])
 	mockSource.
 		EXPECT().
-		Get(internal.NewCurrency("usd"), gomock.Any(), gomock.Any()).
-		DoAndReturn(func(base internal.Currency, targets []internal.Currency, date time.Time) ([]internal.CurrencyRate, error) {
+		Get(gomock.Any(), internal.NewCurrency("usd"), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, base internal.Currency, targets []internal.Currency, date time.Time) ([]internal.CurrencyRate, error) {
 			if base != internal.NewCurrency("usd") {
 				t.Fatalf("unexpected base: %s", base)
 			}
 			return []internal.CurrencyRate{
-				{Base: base, Currency: internal.NewCurrency("eur"), Rate: 0.92, Date: date},
+				{Base: base, Currency: internal.NewCurrency("eur"), Rate: dec("0.92"), Date: date},
 			}, nil
 		})
 	mockSource.
 		EXPECT().
-		Get(internal.NewCurrency("eur"), gomock.Any(), gomock.Any()).
-		DoAndReturn(func(base internal.Currency, targets []internal.Currency, date time.Time) ([]internal.CurrencyRate, error) {
+		Get(gomock.Any(), internal.NewCurrency("eur"), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, base internal.Currency, targets []internal.Currency, date time.Time) ([]internal.CurrencyRate, error) {
 			if base != internal.NewCurrency("eur") {
 				t.Fatalf("unexpected base: %s", base)
 			}
 			return []internal.CurrencyRate{
-				{Base: base, Currency: internal.NewCurrency("usd"), Rate: 1.08, Date: date},
+				{Base: base, Currency: internal.NewCurrency("usd"), Rate: dec("1.08"), Date: date},
 			}, nil
 		})
 
-	// Expect 2 Set calls via repository.Create
+	// Expect 1 SetMany call with the rates of both bases for the date
 	mockStorage.
 		EXPECT().
-		Set(ctx, gomock.AssignableToTypeOf(internal.CurrencyRate{})).
-		Times(2).
+		SetMany(ctx, gomock.Len(2)).
 		Return(nil)
 
-	if err := s.UpdateCurrencyRatesForTodayAndLastNDays(ctx, 0); err != nil {
+	if err := s.Sync(ctx, internal.SyncTriggerSchedule, 0); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
@@ -85,11 +85,420 @@ func TestCurrencySynchronizer_SourceError_Propagates(t *testing.T) {
 
 	mockSource.
 		EXPECT().
-		Get(gomock.Any(), gomock.Any(), gomock.Any()).
-		Return(nil, errors.New("source down"))
+		Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("source down")).
+		Times(2)
 
-	err := s.UpdateCurrencyRatesForTodayAndLastNDays(ctx, 0)
+	err := s.Sync(ctx, internal.SyncTriggerSchedule, 0)
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
+
+	var syncErr *internal.SyncError
+	if !errors.As(err, &syncErr) || len(syncErr.Report.Failed) != 2 {
+		t.Fatalf("expected a sync error with 2 failed units, got %v", err)
+	}
+}
+
+func TestCurrencySynchronizer_Run_ContinuesPastFailedUnits(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockSource := mocks.NewMockCurrencyRateSource(ctrl)
+	mockStorage := mocks.NewMockCurrencyStorage(ctrl)
+	repo := internal.NewCurrencyRepository(mockStorage)
+
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+	s := internal.NewCurrencySynchronizer(*repo, mockSource, []internal.Currency{usd, eur})
+
+	date := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)
+
+	mockSource.
+		EXPECT().
+		Get(gomock.Any(), usd, []internal.Currency{eur}, date).
+		Return(nil, errors.New("source down"))
+	mockSource.
+		EXPECT().
+		Get(gomock.Any(), eur, []internal.Currency{usd}, date).
+		Return([]internal.CurrencyRate{{Base: eur, Currency: usd, Rate: dec("1.08")}}, nil)
+	mockStorage.
+		EXPECT().
+		SetMany(ctx, []internal.CurrencyRate{{Date: date, Base: eur, Currency: usd, Rate: dec("1.08")}}).
+		Return(nil)
+
+	report := s.Run(ctx, internal.SyncTriggerSchedule, []internal.SyncUnit{{Base: usd, Date: date}, {Base: eur, Date: date}})
+
+	if len(report.Failed) != 1 || report.Failed[0].Unit.Base != usd {
+		t.Fatalf("unexpected failed units: %+v", report.Failed)
+	}
+	if len(report.Succeeded) != 1 || report.Succeeded[0].Unit.Base != eur || report.Succeeded[0].RowsWritten != 1 {
+		t.Fatalf("unexpected succeeded units: %+v", report.Succeeded)
+	}
+	if report.RowsWritten() != 1 {
+		t.Fatalf("RowsWritten=%d, want 1", report.RowsWritten())
+	}
+}
+
+func TestCurrencySynchronizer_Run_WritesEachDateInOneTransaction(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockSource := mocks.NewMockCurrencyRateSource(ctrl)
+	mockStorage := mocks.NewMockCurrencyStorage(ctrl)
+	repo := internal.NewCurrencyRepository(mockStorage)
+
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+	s := internal.NewCurrencySynchronizer(*repo, mockSource, []internal.Currency{usd, eur}, internal.WithConcurrency(2))
+
+	date := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)
+
+	mockSource.
+		EXPECT().
+		Get(gomock.Any(), usd, []internal.Currency{eur}, date).
+		Return([]internal.CurrencyRate{{Currency: eur, Rate: dec("0.92")}}, nil)
+	mockSource.
+		EXPECT().
+		Get(gomock.Any(), eur, []internal.Currency{usd}, date).
+		Return([]internal.CurrencyRate{{Currency: usd, Rate: dec("1.08")}}, nil)
+	mockStorage.
+		EXPECT().
+		SetMany(ctx, gomock.Len(2)).
+		Return(errors.New("connection reset"))
+
+	report := s.Run(ctx, internal.SyncTriggerSchedule, []internal.SyncUnit{{Base: usd, Date: date}, {Base: eur, Date: date}})
+
+	if len(report.Failed) != 2 || len(report.Succeeded) != 0 || report.RowsWritten() != 0 {
+		t.Fatalf("expected both units of the date to fail without rows, got %+v", report)
+	}
+}
+
+func TestCurrencySynchronizer_Run_SavesPartialRatesAndReportsTheError(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockSource := mocks.NewMockCurrencyRateSource(ctrl)
+	mockStorage := mocks.NewMockCurrencyStorage(ctrl)
+	repo := internal.NewCurrencyRepository(mockStorage)
+
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+	jpy := internal.NewCurrency("jpy")
+	s := internal.NewCurrencySynchronizer(*repo, mockSource, []internal.Currency{usd, eur, jpy})
+
+	date := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)
+
+	mockSource.
+		EXPECT().
+		Get(gomock.Any(), usd, []internal.Currency{eur, jpy}, date).
+		Return([]internal.CurrencyRate{{Base: usd, Currency: eur, Rate: dec("0.92")}},
+			fmt.Errorf("%w: source #1: %w", internal.ErrIncompleteRates, internal.ErrRateNotFound))
+	mockStorage.
+		EXPECT().
+		SetMany(ctx, []internal.CurrencyRate{{Date: date, Base: usd, Currency: eur, Rate: dec("0.92")}}).
+		Return(nil)
+
+	report := s.Run(ctx, internal.SyncTriggerSchedule, []internal.SyncUnit{{Base: usd, Date: date}})
+
+	if len(report.Failed) != 1 || !errors.Is(report.Failed[0].Err, internal.ErrIncompleteRates) || report.Failed[0].RowsWritten != 1 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+}
+
+func TestCurrencySynchronizer_Run_RetriesRetryableErrors(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockSource := mocks.NewMockCurrencyRateSource(ctrl)
+	mockStorage := mocks.NewMockCurrencyStorage(ctrl)
+	repo := internal.NewCurrencyRepository(mockStorage)
+
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+	s := internal.NewCurrencySynchronizer(*repo, mockSource, []internal.Currency{usd, eur},
+		internal.WithRetryPolicy(internal.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}),
+	)
+
+	date := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)
+
+	gomock.InOrder(
+		mockSource.EXPECT().Get(gomock.Any(), usd, []internal.Currency{eur}, date).Return(nil, errors.New("timeout")),
+		mockSource.EXPECT().Get(gomock.Any(), usd, []internal.Currency{eur}, date).Return([]internal.CurrencyRate{{Currency: eur, Rate: dec("0.92")}}, nil),
+	)
+	mockSource.
+		EXPECT().
+		Get(gomock.Any(), eur, []internal.Currency{usd}, date).
+		Return(nil, internal.ErrRateNotFound)
+	mockStorage.
+		EXPECT().
+		SetMany(ctx, gomock.Any()).
+		Return(nil)
+
+	report := s.Run(ctx, internal.SyncTriggerSchedule, []internal.SyncUnit{{Base: usd, Date: date}, {Base: eur, Date: date}})
+
+	if len(report.Succeeded) != 1 || report.Succeeded[0].Unit.Base != usd {
+		t.Fatalf("unexpected succeeded units: %+v", report.Succeeded)
+	}
+	if len(report.Failed) != 1 || !errors.Is(report.Failed[0].Err, internal.ErrRateNotFound) {
+		t.Fatalf("unexpected failed units: %+v", report.Failed)
+	}
+}
+
+func TestCurrencySynchronizer_Run_ConcurrentReportIsOrdered(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockSource := mocks.NewMockCurrencyRateSource(ctrl)
+	mockStorage := mocks.NewMockCurrencyStorage(ctrl)
+	repo := internal.NewCurrencyRepository(mockStorage)
+
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+	s := internal.NewCurrencySynchronizer(*repo, mockSource, []internal.Currency{usd, eur}, internal.WithConcurrency(4))
+
+	units := make([]internal.SyncUnit, 0)
+	for i := range 10 {
+		units = append(units, internal.SyncUnit{Base: usd, Date: time.Date(2025, 1, 1+i, 0, 0, 0, 0, time.UTC)})
+	}
+
+	mockSource.
+		EXPECT().
+		Get(gomock.Any(), usd, []internal.Currency{eur}, gomock.Any()).
+		DoAndReturn(func(_ context.Context, base internal.Currency, _ []internal.Currency, date time.Time) ([]internal.CurrencyRate, error) {
+			time.Sleep(time.Duration(10-date.Day()) * time.Millisecond)
+			if date.Day()%2 == 0 {
+				return nil, errors.New("source down")
+			}
+			return []internal.CurrencyRate{{Base: base, Currency: eur, Rate: dec("0.92")}}, nil
+		}).
+		Times(len(units))
+	mockStorage.
+		EXPECT().
+		SetMany(ctx, gomock.Any()).
+		Return(nil).
+		Times(5)
+
+	report := s.Run(ctx, internal.SyncTriggerSchedule, units)
+
+	if len(report.Succeeded) != 5 || len(report.Failed) != 5 {
+		t.Fatalf("unexpected report: %d succeeded, %d failed", len(report.Succeeded), len(report.Failed))
+	}
+	for i, result := range report.Failed {
+		if result.Unit.Date.Day() != 2*(i+1) {
+			t.Fatalf("failed[%d] is for %s, want units in input order", i, result.Unit.Date.Format("2006-01-02"))
+		}
+	}
+	for i, result := range report.Succeeded {
+		if result.Unit.Date.Day() != 2*i+1 {
+			t.Fatalf("succeeded[%d] is for %s, want units in input order", i, result.Unit.Date.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestCurrencySynchronizer_Sync_RecordsRunHistory(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockSource := mocks.NewMockCurrencyRateSource(ctrl)
+	mockStorage := mocks.NewMockCurrencyStorage(ctrl)
+	mockRuns := mocks.NewMockSyncRunStorage(ctrl)
+	repo := internal.NewCurrencyRepository(mockStorage)
+
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+	s := internal.NewCurrencySynchronizer(*repo, mockSource, []internal.Currency{usd, eur},
+		internal.WithRunHistory(internal.NewSyncRunRepository(mockRuns)),
+	)
+
+	mockSource.EXPECT().Get(gomock.Any(), usd, gomock.Any(), gomock.Any()).Return([]internal.CurrencyRate{{Currency: eur, Rate: dec("0.92")}}, nil)
+	mockSource.EXPECT().Get(gomock.Any(), eur, gomock.Any(), gomock.Any()).Return(nil, errors.New("source down"))
+	mockStorage.EXPECT().SetMany(ctx, gomock.Any()).Return(nil)
+
+	mockRuns.
+		EXPECT().
+		Create(ctx, gomock.Any()).
+		Return(int64(1), nil)
+	mockRuns.
+		EXPECT().
+		Update(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, run internal.SyncRun) error {
+			if run.ID != 1 || run.Trigger != internal.SyncTriggerStartup || run.Status != internal.SyncRunStatusPartial || run.RowsWritten != 1 || len(run.Errors) != 1 {
+				t.Fatalf("unexpected run: %+v", run)
+			}
+			return nil
+		})
+
+	if err := s.Sync(ctx, internal.SyncTriggerStartup, 0); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestCurrencySynchronizer_UnitsForRange(t *testing.T) {
+	t.Parallel()
+
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+	s := internal.NewCurrencySynchronizer(internal.CurrencyRepository{}, nil, []internal.Currency{usd, eur})
+
+	from := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	units, err := s.UnitsForRange(context.Background(), from, to, []internal.Currency{eur})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(units) != 3 || units[0].Base != eur || !units[0].Date.Equal(from) || !units[2].Date.Equal(to) {
+		t.Fatalf("unexpected units: %+v", units)
+	}
+
+	units, err = s.UnitsForRange(context.Background(), from, to, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(units) != 6 {
+		t.Fatalf("expected 6 units for all bases, got %d", len(units))
+	}
+
+	if _, err := s.UnitsForRange(context.Background(), from, to, []internal.Currency{internal.NewCurrency("jpy")}); err == nil {
+		t.Fatal("expected error for a currency that is not synchronized")
+	}
+}
+
+func TestCurrencySynchronizer_Sync_IncrementalSkipsCompleteDates(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockSource := mocks.NewMockCurrencyRateSource(ctrl)
+	mockStorage := mocks.NewMockCurrencyStorage(ctrl)
+	repo := internal.NewCurrencyRepository(mockStorage)
+
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+	s := internal.NewCurrencySynchronizer(*repo, mockSource, []internal.Currency{usd, eur}, internal.WithFinalizeAfterDays(1))
+
+	today, _ := time.Parse("2006-01-02", time.Now().Format("2006-01-02"))
+	yesterday := today.AddDate(0, 0, -1)
+	dayBefore := today.AddDate(0, 0, -2)
+
+	mockStorage.
+		EXPECT().
+		GetKeys(ctx, dayBefore, yesterday).
+		Return([]internal.CurrencyRateKey{
+			{Date: dayBefore, Base: usd, Currency: eur},
+			{Date: dayBefore, Base: eur, Currency: usd},
+			{Date: yesterday, Base: usd, Currency: eur},
+		}, nil)
+
+	mockSource.
+		EXPECT().
+		Get(gomock.Any(), usd, []internal.Currency{eur}, today).
+		Return([]internal.CurrencyRate{{Currency: eur, Rate: dec("0.9")}}, nil)
+	mockSource.
+		EXPECT().
+		Get(gomock.Any(), eur, []internal.Currency{usd}, today).
+		Return([]internal.CurrencyRate{{Currency: usd, Rate: dec("1.1")}}, nil)
+	mockSource.
+		EXPECT().
+		Get(gomock.Any(), eur, []internal.Currency{usd}, yesterday).
+		Return([]internal.CurrencyRate{{Currency: usd, Rate: dec("1.2")}}, nil)
+
+	// one write per date
+	mockStorage.
+		EXPECT().
+		SetMany(ctx, gomock.Len(2)).
+		Return(nil)
+	mockStorage.
+		EXPECT().
+		SetMany(ctx, gomock.Len(1)).
+		Return(nil)
+
+	if err := s.Sync(ctx, internal.SyncTriggerSchedule, 2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCurrencySynchronizer_IncrementalUnits_RefetchesOnlyUnfinalizedDates(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockSource := mocks.NewMockCurrencyRateSource(ctrl)
+	mockStorage := mocks.NewMockCurrencyStorage(ctrl)
+	repo := internal.NewCurrencyRepository(mockStorage)
+
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+	s := internal.NewCurrencySynchronizer(*repo, mockSource, []internal.Currency{usd, eur}, internal.WithFinalizeAfterDays(2))
+
+	today, _ := time.Parse("2006-01-02", time.Now().Format("2006-01-02"))
+	dayBefore := today.AddDate(0, 0, -2)
+
+	mockStorage.
+		EXPECT().
+		GetKeys(ctx, dayBefore, dayBefore).
+		Return([]internal.CurrencyRateKey{
+			{Date: dayBefore, Base: usd, Currency: eur},
+			{Date: dayBefore, Base: eur, Currency: usd},
+		}, nil)
+
+	units, err := s.IncrementalUnits(ctx, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(units) != 4 {
+		t.Fatalf("got %d units, want 4 (today and yesterday for both bases)", len(units))
+	}
+	for _, unit := range units {
+		if unit.Date.Equal(dayBefore) {
+			t.Fatalf("finalized complete date was scheduled: %+v", unit)
+		}
+	}
+}
+
+func TestCurrencySynchronizer_UnitsForTodayAndLastNDays_TruncatesToTheDay(t *testing.T) {
+	t.Parallel()
+
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+	s := internal.NewCurrencySynchronizer(internal.CurrencyRepository{}, nil, []internal.Currency{usd, eur})
+
+	units, err := s.UnitsForTodayAndLastNDays(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	today, _ := time.Parse("2006-01-02", time.Now().Format("2006-01-02"))
+	want := []time.Time{today, today.AddDate(0, 0, -1), today, today.AddDate(0, 0, -1)}
+	if len(units) != len(want) {
+		t.Fatalf("unexpected units: %+v", units)
+	}
+	for i, unit := range units {
+		if !unit.Date.Equal(want[i]) {
+			t.Fatalf("unit %d: got %s, want %s", i, unit.Date, want[i])
+		}
+	}
 }
@@ -0,0 +1,198 @@
+package ecbeuropa
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fedorov-dmitry/go-test-api/internal"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	provider    = "ecb"
+	euro        = internal.Currency("eur")
+	dailyFeed   = "eurofxref-daily.xml"
+	recentFeed  = "eurofxref-hist-90d.xml"
+	historyFeed = "eurofxref-hist.xml"
+	recentDays  = 90
+
+	requestTimeout = time.Minute
+	feedTTL        = 15 * time.Minute
+)
+
+type feedRates map[string]map[internal.Currency]decimal.Decimal
+
+type cachedFeed struct {
+	mu        sync.Mutex
+	rates     feedRates
+	fetchedAt time.Time
+}
+
+type CurrencyRateSource struct {
+	baseURL string
+	client  *http.Client
+	ttl     time.Duration
+
+	mu    sync.Mutex
+	feeds map[string]*cachedFeed
+}
+
+func NewCurrencyRateSource(baseURL string) *CurrencyRateSource {
+	return &CurrencyRateSource{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  &http.Client{Timeout: requestTimeout},
+		ttl:     feedTTL,
+		feeds:   make(map[string]*cachedFeed),
+	}
+}
+
+type envelope struct {
+	Cube struct {
+		Days []struct {
+			Time  string `xml:"time,attr"`
+			Rates []struct {
+				Currency string          `xml:"currency,attr"`
+				Rate     decimal.Decimal `xml:"rate,attr"`
+			} `xml:"Cube"`
+		} `xml:"Cube"`
+	} `xml:"Cube"`
+}
+
+func (s *CurrencyRateSource) Get(ctx context.Context, baseCurrency internal.Currency, currencies []internal.Currency, date time.Time) ([]internal.CurrencyRate, error) {
+	day := date.Format("2006-01-02")
+
+	var euroRates map[internal.Currency]decimal.Decimal
+	for _, feed := range feedsFor(date) {
+		rates, err := s.feed(ctx, feed)
+		if err != nil {
+			return nil, err
+		}
+
+		if r, ok := rates[day]; ok {
+			euroRates = r
+			break
+		}
+	}
+
+	if euroRates == nil {
+		return nil, fmt.Errorf("no ECB reference rates published for %s: %w", day, internal.ErrRateNotFound)
+	}
+
+	baseRate, ok := euroRate(euroRates, baseCurrency)
+	if !ok || baseRate.IsZero() {
+		return nil, fmt.Errorf("no ECB reference rate for %s on %s: %w", baseCurrency, day, internal.ErrRateNotFound)
+	}
+
+	currencyRates := make([]internal.CurrencyRate, 0, len(currencies))
+
+	for _, currency := range currencies {
+		rate, ok := euroRate(euroRates, currency)
+		if !ok {
+			continue
+		}
+
+		currencyRates = append(currencyRates, internal.CurrencyRate{
+			Date:     date,
+			Base:     baseCurrency,
+			Currency: currency,
+			Rate:     rate.Div(baseRate),
+			Derived:  baseCurrency != euro,
+			Provider: provider,
+		})
+	}
+
+	return currencyRates, nil
+}
+
+func euroRate(rates map[internal.Currency]decimal.Decimal, currency internal.Currency) (decimal.Decimal, bool) {
+	if currency == euro {
+		return decimal.NewFromInt(1), true
+	}
+
+	rate, ok := rates[currency]
+	return rate, ok
+}
+
+func (s *CurrencyRateSource) feed(ctx context.Context, name string) (feedRates, error) {
+	s.mu.Lock()
+	cached, ok := s.feeds[name]
+	if !ok {
+		cached = &cachedFeed{}
+		s.feeds[name] = cached
+	}
+	s.mu.Unlock()
+
+	cached.mu.Lock()
+	defer cached.mu.Unlock()
+
+	if cached.rates != nil && time.Since(cached.fetchedAt) < s.ttl {
+		return cached.rates, nil
+	}
+
+	rates, err := s.fetch(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	cached.rates = rates
+	cached.fetchedAt = time.Now()
+
+	return rates, nil
+}
+
+func (s *CurrencyRateSource) fetch(ctx context.Context, feed string) (feedRates, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.baseURL+"/"+feed, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ECB reference rates from %s: %w", feed, err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ECB reference rates from %s: %w", feed, err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("bad response from ECB. %w", &internal.HttpStatusError{StatusCode: resp.StatusCode, Status: resp.Status, Body: string(body)})
+	}
+
+	var result envelope
+
+	err = xml.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode ECB reference rates: %w", err)
+	}
+
+	rates := make(feedRates, len(result.Cube.Days))
+	for _, d := range result.Cube.Days {
+		dayRates := make(map[internal.Currency]decimal.Decimal, len(d.Rates))
+		for _, r := range d.Rates {
+			dayRates[internal.NewCurrency(r.Currency)] = r.Rate
+		}
+
+		rates[d.Time] = dayRates
+	}
+
+	return rates, nil
+}
+
+func feedsFor(date time.Time) []string {
+	age := time.Since(date)
+
+	switch {
+	case age < 24*time.Hour:
+		return []string{dailyFeed, recentFeed}
+	case age < recentDays*24*time.Hour:
+		return []string{recentFeed}
+	default:
+		return []string{historyFeed}
+	}
+}
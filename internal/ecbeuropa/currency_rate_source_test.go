@@ -0,0 +1,156 @@
+package ecbeuropa_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fedorov-dmitry/go-test-api/internal"
+	"github.com/fedorov-dmitry/go-test-api/internal/ecbeuropa"
+	"github.com/shopspring/decimal"
+)
+
+func dec(value string) decimal.Decimal {
+	return decimal.RequireFromString(value)
+}
+
+const feed = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<gesmes:Sender>
+		<gesmes:name>European Central Bank</gesmes:name>
+	</gesmes:Sender>
+	<Cube>
+		<Cube time='2025-01-14'>
+			<Cube currency='USD' rate='1.03'/>
+			<Cube currency='JPY' rate='162.0'/>
+		</Cube>
+		<Cube time='2025-01-13'>
+			<Cube currency='USD' rate='1.25'/>
+			<Cube currency='JPY' rate='160.0'/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`
+
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eurofxref-hist.xml" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(feed))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestCurrencyRateSource_Get_EuroBase(t *testing.T) {
+	t.Parallel()
+
+	source := ecbeuropa.NewCurrencyRateSource(newServer(t).URL)
+
+	date := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)
+	eur := internal.NewCurrency("eur")
+	targets := []internal.Currency{internal.NewCurrency("usd"), internal.NewCurrency("jpy")}
+
+	rates, err := source.Get(context.Background(), eur, targets, date)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rates) != 2 {
+		t.Fatalf("expected 2 rates, got %d", len(rates))
+	}
+	if rates[0].Base != eur || rates[0].Currency != internal.NewCurrency("usd") || !rates[0].Rate.Equal(dec("1.25")) || rates[0].Derived || rates[0].Provider != "ecb" || !rates[0].Date.Equal(date) {
+		t.Fatalf("unexpected first rate: %+v", rates[0])
+	}
+	if rates[1].Currency != internal.NewCurrency("jpy") || !rates[1].Rate.Equal(dec("160")) {
+		t.Fatalf("unexpected second rate: %+v", rates[1])
+	}
+}
+
+func TestCurrencyRateSource_Get_DerivesOtherBase(t *testing.T) {
+	t.Parallel()
+
+	source := ecbeuropa.NewCurrencyRateSource(newServer(t).URL + "/")
+
+	date := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)
+	usd := internal.NewCurrency("usd")
+	targets := []internal.Currency{internal.NewCurrency("eur"), internal.NewCurrency("jpy"), internal.NewCurrency("xyz")}
+
+	rates, err := source.Get(context.Background(), usd, targets, date)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rates) != 2 {
+		t.Fatalf("expected 2 rates, got %+v", rates)
+	}
+	if rates[0].Currency != internal.NewCurrency("eur") || !rates[0].Rate.Equal(dec("0.8")) || !rates[0].Derived {
+		t.Fatalf("unexpected first rate: %+v", rates[0])
+	}
+	if rates[1].Currency != internal.NewCurrency("jpy") || !rates[1].Rate.Equal(dec("128")) || !rates[1].Derived {
+		t.Fatalf("unexpected second rate: %+v", rates[1])
+	}
+}
+
+func TestCurrencyRateSource_Get_ReusesDownloadedFeed(t *testing.T) {
+	t.Parallel()
+
+	var downloads atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downloads.Add(1)
+		_, _ = w.Write([]byte(feed))
+	}))
+	defer srv.Close()
+
+	source := ecbeuropa.NewCurrencyRateSource(srv.URL)
+
+	targets := []internal.Currency{internal.NewCurrency("eur"), internal.NewCurrency("usd"), internal.NewCurrency("jpy")}
+	for _, base := range targets {
+		for _, day := range []int{13, 14} {
+			_, err := source.Get(context.Background(), base, targets, time.Date(2025, 1, day, 0, 0, 0, 0, time.UTC))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+	}
+
+	if got := downloads.Load(); got != 1 {
+		t.Fatalf("expected the history feed to be downloaded once, got %d downloads", got)
+	}
+}
+
+func TestCurrencyRateSource_Get_DateNotPublished(t *testing.T) {
+	t.Parallel()
+
+	source := ecbeuropa.NewCurrencyRateSource(newServer(t).URL)
+
+	date := time.Date(2025, 1, 11, 0, 0, 0, 0, time.UTC)
+
+	_, err := source.Get(context.Background(), internal.NewCurrency("eur"), []internal.Currency{internal.NewCurrency("usd")}, date)
+	if !errors.Is(err, internal.ErrRateNotFound) {
+		t.Fatalf("expected ErrRateNotFound, got %v", err)
+	}
+}
+
+func TestCurrencyRateSource_Get_BadXML(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<not-xml"))
+	}))
+	defer srv.Close()
+
+	source := ecbeuropa.NewCurrencyRateSource(srv.URL)
+
+	_, err := source.Get(context.Background(), internal.NewCurrency("eur"), []internal.Currency{internal.NewCurrency("usd")}, time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC))
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
@@ -0,0 +1,172 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+type CurrencyRateGap struct {
+	Date    time.Time
+	Base    Currency
+	Missing []Currency
+}
+
+type GapReport struct {
+	From         time.Time
+	To           time.Time
+	MissingRates int
+	Unfillable   int
+	Gaps         []CurrencyRateGap
+}
+
+const unfillableKeyTTL = 24 * time.Hour
+
+type unfillableKeys struct {
+	mu   sync.Mutex
+	keys map[CurrencyRateKey]time.Time
+}
+
+func newUnfillableKeys() *unfillableKeys {
+	return &unfillableKeys{keys: make(map[CurrencyRateKey]time.Time)}
+}
+
+func (u *unfillableKeys) add(date time.Time, base Currency, currencies []Currency) {
+	if u == nil || len(currencies) == 0 {
+		return
+	}
+
+	date, _ = time.Parse("2006-01-02", date.Format("2006-01-02"))
+	now := time.Now()
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	maps.DeleteFunc(u.keys, func(_ CurrencyRateKey, recordedAt time.Time) bool {
+		return now.Sub(recordedAt) >= unfillableKeyTTL
+	})
+
+	for _, currency := range currencies {
+		u.keys[CurrencyRateKey{Date: date, Base: base, Currency: currency}] = now
+	}
+}
+
+func (u *unfillableKeys) contains(key CurrencyRateKey) bool {
+	if u == nil {
+		return false
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	recordedAt, ok := u.keys[key]
+
+	return ok && time.Since(recordedAt) < unfillableKeyTTL
+}
+
+func (c *CurrencySynchronizer) recordUnfillable(fetched fetchedUnit) {
+	unit := fetched.result.Unit
+
+	today, _ := time.Parse("2006-01-02", time.Now().Format("2006-01-02"))
+	if unit.Date.After(today.AddDate(0, 0, -max(c.finalizeAfter, 1))) {
+		return
+	}
+
+	targets := unit.Currencies
+	if len(targets) == 0 {
+		targets = c.otherCurrencies(unit.Base)
+	}
+
+	switch {
+	case errors.Is(fetched.result.Err, ErrRateNotFound):
+		c.unfillable.add(unit.Date, unit.Base, targets)
+	case fetched.result.Err == nil && (fetched.fetchErr == nil || errors.Is(fetched.fetchErr, ErrRateNotFound)):
+		missing := slices.DeleteFunc(slices.Clone(targets), func(currency Currency) bool {
+			return slices.ContainsFunc(fetched.rates, func(rate CurrencyRate) bool { return rate.Currency == currency })
+		})
+		c.unfillable.add(unit.Date, unit.Base, missing)
+	}
+}
+
+func (c *CurrencySynchronizer) DetectGaps(ctx context.Context) (GapReport, error) {
+	c, err := c.withTrackedCurrencies(ctx)
+	if err != nil {
+		return GapReport{}, err
+	}
+
+	today, _ := time.Parse("2006-01-02", time.Now().Format("2006-01-02"))
+
+	return c.detectGaps(ctx, today.AddDate(0, 0, -c.retention), today.AddDate(0, 0, -1))
+}
+
+func (c *CurrencySynchronizer) detectGaps(ctx context.Context, from time.Time, to time.Time) (GapReport, error) {
+	report := GapReport{
+		From: from,
+		To:   to,
+		Gaps: make([]CurrencyRateGap, 0),
+	}
+
+	if report.To.Before(report.From) {
+		return report, nil
+	}
+
+	keys, err := c.repository.GetKeys(ctx, report.From, report.To)
+	if err != nil {
+		return GapReport{}, fmt.Errorf("failed to detect currency rate gaps: %w", err)
+	}
+
+	stored := make(map[CurrencyRateKey]bool, len(keys))
+	for _, key := range keys {
+		key.Date, _ = time.Parse("2006-01-02", key.Date.Format("2006-01-02"))
+		stored[key] = true
+	}
+
+	for date := report.From; !date.After(report.To); date = date.AddDate(0, 0, 1) {
+		for _, base := range c.currencies {
+			missing := make([]Currency, 0)
+			for _, currency := range c.otherCurrencies(base) {
+				key := CurrencyRateKey{Date: date, Base: base, Currency: currency}
+				switch {
+				case stored[key]:
+				case c.unfillable.contains(key):
+					report.Unfillable++
+				default:
+					missing = append(missing, currency)
+				}
+			}
+
+			if len(missing) > 0 {
+				report.Gaps = append(report.Gaps, CurrencyRateGap{Date: date, Base: base, Missing: missing})
+				report.MissingRates += len(missing)
+			}
+		}
+	}
+
+	return report, nil
+}
+
+func (r GapReport) Units() []SyncUnit {
+	units := make([]SyncUnit, len(r.Gaps))
+	for i, gap := range r.Gaps {
+		units[i] = SyncUnit{Base: gap.Base, Date: gap.Date, Currencies: gap.Missing}
+	}
+
+	return units
+}
+
+func (c *CurrencySynchronizer) FillGaps(ctx context.Context) error {
+	gaps, err := c.DetectGaps(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(gaps.Gaps) == 0 {
+		return nil
+	}
+
+	return c.Run(ctx, SyncTriggerGapFill, gaps.Units()).Err()
+}
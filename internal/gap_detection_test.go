@@ -0,0 +1,207 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fedorov-dmitry/go-test-api/internal"
+	"github.com/fedorov-dmitry/go-test-api/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCurrencySynchronizer_DetectGaps(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockSource := mocks.NewMockCurrencyRateSource(ctrl)
+	mockStorage := mocks.NewMockCurrencyStorage(ctrl)
+	repo := internal.NewCurrencyRepository(mockStorage)
+
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+	jpy := internal.NewCurrency("jpy")
+	s := internal.NewCurrencySynchronizer(*repo, mockSource, []internal.Currency{usd, eur, jpy}, internal.WithRetentionDays(2))
+
+	today, _ := time.Parse("2006-01-02", time.Now().Format("2006-01-02"))
+	dayBefore := today.AddDate(0, 0, -2)
+	yesterday := today.AddDate(0, 0, -1)
+
+	keys := make([]internal.CurrencyRateKey, 0)
+	for _, date := range []time.Time{dayBefore, yesterday} {
+		for _, pair := range [][2]internal.Currency{{usd, eur}, {usd, jpy}, {eur, usd}, {eur, jpy}, {jpy, usd}, {jpy, eur}} {
+			if date.Equal(yesterday) && pair[0] == usd && pair[1] == jpy {
+				continue
+			}
+			keys = append(keys, internal.CurrencyRateKey{Date: date, Base: pair[0], Currency: pair[1]})
+		}
+	}
+	keys = keys[1:] // drop usd-eur for the day before yesterday
+
+	mockStorage.
+		EXPECT().
+		GetKeys(ctx, dayBefore, yesterday).
+		Return(keys, nil)
+
+	report, err := s.DetectGaps(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.MissingRates != 2 || len(report.Gaps) != 2 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	if !report.Gaps[0].Date.Equal(dayBefore) || report.Gaps[0].Base != usd || len(report.Gaps[0].Missing) != 1 || report.Gaps[0].Missing[0] != eur {
+		t.Fatalf("unexpected first gap: %+v", report.Gaps[0])
+	}
+	if !report.Gaps[1].Date.Equal(yesterday) || report.Gaps[1].Base != usd || report.Gaps[1].Missing[0] != jpy {
+		t.Fatalf("unexpected second gap: %+v", report.Gaps[1])
+	}
+
+	units := report.Units()
+	if len(units) != 2 || len(units[1].Currencies) != 1 || units[1].Currencies[0] != jpy {
+		t.Fatalf("unexpected units: %+v", units)
+	}
+}
+
+func TestCurrencySynchronizer_FillGaps_FetchesOnlyMissingRates(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockSource := mocks.NewMockCurrencyRateSource(ctrl)
+	mockStorage := mocks.NewMockCurrencyStorage(ctrl)
+	repo := internal.NewCurrencyRepository(mockStorage)
+
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+	jpy := internal.NewCurrency("jpy")
+	s := internal.NewCurrencySynchronizer(*repo, mockSource, []internal.Currency{usd, eur, jpy}, internal.WithRetentionDays(1))
+
+	today, _ := time.Parse("2006-01-02", time.Now().Format("2006-01-02"))
+	yesterday := today.AddDate(0, 0, -1)
+
+	mockStorage.
+		EXPECT().
+		GetKeys(ctx, yesterday, yesterday).
+		Return([]internal.CurrencyRateKey{
+			{Date: yesterday, Base: usd, Currency: eur},
+			{Date: yesterday, Base: eur, Currency: usd},
+			{Date: yesterday, Base: eur, Currency: jpy},
+			{Date: yesterday, Base: jpy, Currency: usd},
+			{Date: yesterday, Base: jpy, Currency: eur},
+		}, nil)
+	mockSource.
+		EXPECT().
+		Get(gomock.Any(), usd, []internal.Currency{jpy}, yesterday).
+		Return([]internal.CurrencyRate{{Currency: jpy, Rate: dec("145.1")}}, nil)
+	mockStorage.
+		EXPECT().
+		SetMany(ctx, []internal.CurrencyRate{{Date: yesterday, Base: usd, Currency: jpy, Rate: dec("145.1")}}).
+		Return(nil)
+
+	if err := s.FillGaps(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCurrencySynchronizer_FillGaps_SkipsRatesTheSourceDoesNotHave(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockSource := mocks.NewMockCurrencyRateSource(ctrl)
+	mockStorage := mocks.NewMockCurrencyStorage(ctrl)
+	repo := internal.NewCurrencyRepository(mockStorage)
+
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+	s := internal.NewCurrencySynchronizer(*repo, mockSource, []internal.Currency{usd, eur}, internal.WithRetentionDays(1))
+
+	today, _ := time.Parse("2006-01-02", time.Now().Format("2006-01-02"))
+	yesterday := today.AddDate(0, 0, -1)
+
+	mockStorage.
+		EXPECT().
+		GetKeys(ctx, yesterday, yesterday).
+		Return([]internal.CurrencyRateKey{{Date: yesterday, Base: eur, Currency: usd}}, nil).
+		Times(2)
+	mockSource.
+		EXPECT().
+		Get(gomock.Any(), usd, []internal.Currency{eur}, yesterday).
+		Return(nil, fmt.Errorf("no rates published: %w", internal.ErrRateNotFound)).
+		Times(1)
+	mockStorage.EXPECT().SetMany(ctx, gomock.Len(0)).Return(nil).AnyTimes()
+
+	if err := s.FillGaps(ctx); !errors.Is(err, internal.ErrRateNotFound) {
+		t.Fatalf("expected ErrRateNotFound, got %v", err)
+	}
+
+	report, err := s.DetectGaps(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Gaps) != 0 || report.MissingRates != 0 || report.Unfillable != 1 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+}
+
+func TestCurrencySynchronizer_FillGaps_SkipsCurrenciesMissingFromASuccessfulFetch(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockSource := mocks.NewMockCurrencyRateSource(ctrl)
+	mockStorage := mocks.NewMockCurrencyStorage(ctrl)
+	repo := internal.NewCurrencyRepository(mockStorage)
+
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+	xau := internal.NewCurrency("xau")
+	s := internal.NewCurrencySynchronizer(*repo, mockSource, []internal.Currency{usd, eur, xau}, internal.WithRetentionDays(1))
+
+	today, _ := time.Parse("2006-01-02", time.Now().Format("2006-01-02"))
+	yesterday := today.AddDate(0, 0, -1)
+
+	mockStorage.
+		EXPECT().
+		GetKeys(ctx, yesterday, yesterday).
+		Return([]internal.CurrencyRateKey{
+			{Date: yesterday, Base: eur, Currency: usd},
+			{Date: yesterday, Base: eur, Currency: xau},
+			{Date: yesterday, Base: xau, Currency: usd},
+			{Date: yesterday, Base: xau, Currency: eur},
+		}, nil).
+		Times(2)
+	mockSource.
+		EXPECT().
+		Get(gomock.Any(), usd, []internal.Currency{eur, xau}, yesterday).
+		Return([]internal.CurrencyRate{{Currency: eur, Rate: dec("0.92")}}, nil).
+		Times(1)
+	mockStorage.
+		EXPECT().
+		SetMany(ctx, []internal.CurrencyRate{{Date: yesterday, Base: usd, Currency: eur, Rate: dec("0.92")}}).
+		Return(nil)
+
+	if err := s.FillGaps(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	report, err := s.DetectGaps(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Gaps) != 1 || report.Unfillable != 1 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+}
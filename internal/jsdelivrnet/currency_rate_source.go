@@ -1,49 +1,86 @@
 package jsdelivrnet
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/fedorov-dmitry/go-test-api/internal"
+	"github.com/shopspring/decimal"
 )
 
+const requestTimeout = 30 * time.Second
+
 type CurrencyRateSource struct {
-	baseURL string
+	baseURL  string
+	provider string
+	client   *http.Client
 }
 
 func NewCurrencyRateSource(baseURL string) *CurrencyRateSource {
-	return &CurrencyRateSource{baseURL: baseURL}
+	provider := baseURL
+	if u, err := url.Parse(baseURL); err == nil && u.Host != "" {
+		provider = u.Host
+	}
+
+	return &CurrencyRateSource{baseURL: baseURL, provider: provider, client: &http.Client{Timeout: requestTimeout}}
 }
 
-func (s *CurrencyRateSource) Get(baseCurrency internal.Currency, currencies []internal.Currency, date time.Time) ([]internal.CurrencyRate, error) {
+func (s *CurrencyRateSource) Get(ctx context.Context, baseCurrency internal.Currency, currencies []internal.Currency, date time.Time) ([]internal.CurrencyRate, error) {
 	url := fmt.Sprintf("%s@%s/v1/currencies/%s.json", s.baseURL, date.Format("2006-01-02"), baseCurrency)
 
-	resp, err := http.Get(url)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rates for %s from external API: %w", baseCurrency, err)
+	}
+
+	resp, err := s.client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get rates for %s from external API: %w", baseCurrency, err)
 	}
 
 	defer resp.Body.Close() // do I need to handle an error here?
 
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("rates API has no rates for %s for %s: %w", baseCurrency, date.Format("2006-01-02"), internal.ErrRateNotFound)
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("bad response from rates API. status: %s. response: %s", resp.Status, string(body))
+		return nil, fmt.Errorf("bad response from rates API. %w", &internal.HttpStatusError{StatusCode: resp.StatusCode, Status: resp.Status, Body: string(body)})
 	}
 
 	var result map[internal.Currency]interface{}
 
-	err = json.NewDecoder(resp.Body).Decode(&result)
+	decoder := json.NewDecoder(resp.Body)
+	decoder.UseNumber()
+
+	err = decoder.Decode(&result)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode rates API response: %w", err)
 	}
 
-	currencyRates := make([]internal.CurrencyRate, len(currencies))
+	baseRates, ok := result[baseCurrency].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("rates API response has no rates for %s", baseCurrency)
+	}
+
+	currencyRates := make([]internal.CurrencyRate, 0, len(currencies))
+
+	for _, currency := range currencies {
+		number, ok := baseRates[string(currency)].(json.Number)
+		if !ok {
+			continue
+		}
 
-	for i, currency := range currencies {
-		rate := result[baseCurrency].(map[string]interface{})[string(currency)].(float64)
+		rate, err := decimal.NewFromString(number.String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse rates API rate for %s: %w", currency, err)
+		}
 
 		currencyRate := internal.CurrencyRate{}
 
@@ -51,8 +88,9 @@ func (s *CurrencyRateSource) Get(baseCurrency internal.Currency, currencies []in
 		currencyRate.Base = baseCurrency
 		currencyRate.Currency = currency
 		currencyRate.Date = date
+		currencyRate.Provider = s.provider
 
-		currencyRates[i] = currencyRate
+		currencyRates = append(currencyRates, currencyRate)
 	}
 
 	return currencyRates, nil
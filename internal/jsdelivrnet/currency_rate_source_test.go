@@ -1,7 +1,9 @@
 package jsdelivrnet_test
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -9,8 +11,13 @@ import (
 
 	"github.com/fedorov-dmitry/go-test-api/internal"
 	"github.com/fedorov-dmitry/go-test-api/internal/jsdelivrnet"
+	"github.com/shopspring/decimal"
 )
 
+func dec(value string) decimal.Decimal {
+	return decimal.RequireFromString(value)
+}
+
 func TestCurrencyRateSource_Get_Success(t *testing.T) {
 	t.Parallel()
 
@@ -33,21 +40,51 @@ func TestCurrencyRateSource_Get_Success(t *testing.T) {
 	base := internal.NewCurrency("usd")
 	targets := []internal.Currency{internal.NewCurrency("eur"), internal.NewCurrency("jpy")}
 
-	rates, err := source.Get(base, targets, date)
+	rates, err := source.Get(context.Background(), base, targets, date)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 	if len(rates) != 2 {
 		t.Fatalf("expected 2 rates, got %d", len(rates))
 	}
-	if rates[0].Base != base || rates[0].Currency != internal.NewCurrency("eur") || rates[0].Rate != 0.92 || !rates[0].Date.Equal(date) {
+	if rates[0].Base != base || rates[0].Currency != internal.NewCurrency("eur") || !rates[0].Rate.Equal(dec("0.92")) || !rates[0].Date.Equal(date) {
 		t.Fatalf("unexpected first rate: %+v", rates[0])
 	}
-	if rates[1].Base != base || rates[1].Currency != internal.NewCurrency("jpy") || rates[1].Rate != 145.1 || !rates[1].Date.Equal(date) {
+	if rates[1].Base != base || rates[1].Currency != internal.NewCurrency("jpy") || !rates[1].Rate.Equal(dec("145.1")) || !rates[1].Date.Equal(date) {
 		t.Fatalf("unexpected second rate: %+v", rates[1])
 	}
 }
 
+func TestCurrencyRateSource_Get_SkipsMissingCurrencies(t *testing.T) {
+	t.Parallel()
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := map[string]map[string]float64{
+			"usd": {
+				"eur": 0.92,
+			},
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	source := jsdelivrnet.NewCurrencyRateSource(srv.URL + "/")
+
+	targets := []internal.Currency{internal.NewCurrency("eur"), internal.NewCurrency("xyz")}
+
+	rates, err := source.Get(context.Background(), internal.NewCurrency("usd"), targets, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rates) != 1 || rates[0].Currency != internal.NewCurrency("eur") {
+		t.Fatalf("unexpected rates: %+v", rates)
+	}
+	if rates[0].Provider != srv.Listener.Addr().String() {
+		t.Fatalf("unexpected provider: %s", rates[0].Provider)
+	}
+}
+
 func TestCurrencyRateSource_Get_BadStatus(t *testing.T) {
 	t.Parallel()
 
@@ -59,12 +96,29 @@ func TestCurrencyRateSource_Get_BadStatus(t *testing.T) {
 
 	source := jsdelivrnet.NewCurrencyRateSource(srv.URL + "/")
 
-	_, err := source.Get(internal.NewCurrency("usd"), []internal.Currency{internal.NewCurrency("eur")}, time.Now())
+	_, err := source.Get(context.Background(), internal.NewCurrency("usd"), []internal.Currency{internal.NewCurrency("eur")}, time.Now())
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
 }
 
+func TestCurrencyRateSource_Get_NotFound(t *testing.T) {
+	t.Parallel()
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	source := jsdelivrnet.NewCurrencyRateSource(srv.URL + "/")
+
+	_, err := source.Get(context.Background(), internal.NewCurrency("usd"), []internal.Currency{internal.NewCurrency("eur")}, time.Now())
+	if !errors.Is(err, internal.ErrRateNotFound) {
+		t.Fatalf("expected ErrRateNotFound, got %v", err)
+	}
+}
+
 func TestCurrencyRateSource_Get_BadJSON(t *testing.T) {
 	t.Parallel()
 
@@ -76,7 +130,7 @@ func TestCurrencyRateSource_Get_BadJSON(t *testing.T) {
 
 	source := jsdelivrnet.NewCurrencyRateSource(srv.URL + "/")
 
-	_, err := source.Get(internal.NewCurrency("usd"), []internal.Currency{internal.NewCurrency("eur")}, time.Now())
+	_, err := source.Get(context.Background(), internal.NewCurrency("usd"), []internal.Currency{internal.NewCurrency("eur")}, time.Now())
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
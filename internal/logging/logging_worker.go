@@ -5,18 +5,19 @@ import (
 	"log"
 
 	"github.com/fedorov-dmitry/go-test-api/internal/middleware"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-func StartLoggingWorker(ctx context.Context, pgPool *pgxpool.Pool, logCh <-chan middleware.RequestLog) {
-	sql := "INSERT INTO app.logs (timestamp, path) VALUES ($1, $2)"
+type RequestLogStorage interface {
+	Add(ctx context.Context, entry middleware.RequestLog) error
+}
 
+func StartLoggingWorker(ctx context.Context, storage RequestLogStorage, logCh <-chan middleware.RequestLog) {
 	go func() {
 		for entry := range logCh {
-			_, err := pgPool.Exec(ctx, sql, entry.Timestamp, entry.Path)
+			err := storage.Add(ctx, entry)
 
 			if err != nil {
-				log.Println("failed to insert log entry:", err)
+				log.Println(err)
 			}
 		}
 	}()
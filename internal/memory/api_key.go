@@ -0,0 +1,88 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/fedorov-dmitry/go-test-api/internal"
+)
+
+type ApiKeyStorage struct {
+	mu     sync.RWMutex
+	keys   []internal.ApiKey
+	nextID int64
+}
+
+func NewApiKeyStorage() *ApiKeyStorage {
+	return &ApiKeyStorage{keys: make([]internal.ApiKey, 0), nextID: 1}
+}
+
+func (s *ApiKeyStorage) Create(ctx context.Context, key internal.ApiKey) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, existing := range s.keys {
+		if existing.Hash == key.Hash {
+			return 0, fmt.Errorf("failed to create api key %q: duplicate key hash", key.Name)
+		}
+	}
+
+	key.ID = s.nextID
+	s.nextID++
+
+	s.keys = append(s.keys, key)
+
+	return key.ID, nil
+}
+
+func (s *ApiKeyStorage) Get(ctx context.Context, id int64) (internal.ApiKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, key := range s.keys {
+		if key.ID == id {
+			return key, nil
+		}
+	}
+
+	return internal.ApiKey{}, fmt.Errorf("failed to get api key %d: %w", id, internal.ErrApiKeyNotFound)
+}
+
+func (s *ApiKeyStorage) GetByHash(ctx context.Context, hash string) (internal.ApiKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, key := range s.keys {
+		if key.Hash == hash {
+			return key, nil
+		}
+	}
+
+	return internal.ApiKey{}, fmt.Errorf("failed to get api key: %w", internal.ErrApiKeyNotFound)
+}
+
+func (s *ApiKeyStorage) List(ctx context.Context) ([]internal.ApiKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]internal.ApiKey, len(s.keys))
+	copy(keys, s.keys)
+
+	return keys, nil
+}
+
+func (s *ApiKeyStorage) Update(ctx context.Context, key internal.ApiKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.keys {
+		if s.keys[i].ID == key.ID {
+			s.keys[i].Prefix = key.Prefix
+			s.keys[i].Hash = key.Hash
+			s.keys[i].RevokedAt = key.RevokedAt
+		}
+	}
+
+	return nil
+}
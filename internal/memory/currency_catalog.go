@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/fedorov-dmitry/go-test-api/internal"
+)
+
+type CurrencyCatalogStorage struct {
+	mu         sync.RWMutex
+	currencies map[internal.Currency]internal.CurrencyInfo
+}
+
+func NewCurrencyCatalogStorage() *CurrencyCatalogStorage {
+	return &CurrencyCatalogStorage{currencies: make(map[internal.Currency]internal.CurrencyInfo)}
+}
+
+func (s *CurrencyCatalogStorage) List(ctx context.Context) ([]internal.CurrencyInfo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	currencies := make([]internal.CurrencyInfo, 0, len(s.currencies))
+	for _, currency := range s.currencies {
+		currencies = append(currencies, currency)
+	}
+
+	slices.SortFunc(currencies, func(a, b internal.CurrencyInfo) int {
+		return cmp.Compare(a.Code, b.Code)
+	})
+
+	return currencies, nil
+}
+
+func (s *CurrencyCatalogStorage) Seed(ctx context.Context, currencies []internal.CurrencyInfo) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, currency := range currencies {
+		if _, ok := s.currencies[currency.Code]; !ok {
+			s.currencies[currency.Code] = currency
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,197 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/fedorov-dmitry/go-test-api/internal"
+)
+
+type currencyPair struct {
+	base     internal.Currency
+	currency internal.Currency
+}
+
+type CurrencyStorage struct {
+	mu         sync.RWMutex
+	revisions  map[internal.CurrencyRateKey][]internal.CurrencyRateRevision
+	dates      map[currencyPair][]time.Time
+	currencies map[internal.Currency][]internal.Currency
+}
+
+func NewCurrencyStorage() *CurrencyStorage {
+	return &CurrencyStorage{
+		revisions:  make(map[internal.CurrencyRateKey][]internal.CurrencyRateRevision),
+		dates:      make(map[currencyPair][]time.Time),
+		currencies: make(map[internal.Currency][]internal.Currency),
+	}
+}
+
+func (c *CurrencyStorage) Get(ctx context.Context, baseCurrency internal.Currency, currency internal.Currency, date time.Time) (internal.CurrencyRate, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	rate, ok := c.current(ctx, internal.CurrencyRateKey{Date: day(date), Base: baseCurrency, Currency: currency})
+	if !ok {
+		return internal.CurrencyRate{}, fmt.Errorf("failed to get currency rates for %s-%s: %w", baseCurrency, currency, internal.ErrRateNotFound)
+	}
+
+	return rate, nil
+}
+
+func (c *CurrencyStorage) GetLatest(ctx context.Context, baseCurrency internal.Currency, currency internal.Currency, date time.Time) (internal.CurrencyRate, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	latest, ok := c.latest(ctx, baseCurrency, currency, time.Time{}, day(date))
+	if !ok {
+		return internal.CurrencyRate{}, fmt.Errorf("failed to get latest currency rate for %s-%s: %w", baseCurrency, currency, internal.ErrRateNotFound)
+	}
+
+	return latest, nil
+}
+
+func (c *CurrencyStorage) GetLatestMany(ctx context.Context, baseCurrency internal.Currency, from time.Time, to time.Time) ([]internal.CurrencyRate, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	rates := make([]internal.CurrencyRate, 0)
+	for _, currency := range c.currencies[baseCurrency] {
+		if rate, ok := c.latest(ctx, baseCurrency, currency, day(from), day(to)); ok {
+			rates = append(rates, rate)
+		}
+	}
+
+	return rates, nil
+}
+
+func (c *CurrencyStorage) GetMany(ctx context.Context, baseCurrency internal.Currency, date time.Time) ([]internal.CurrencyRate, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	rates := make([]internal.CurrencyRate, 0)
+	for _, currency := range c.currencies[baseCurrency] {
+		if rate, ok := c.current(ctx, internal.CurrencyRateKey{Date: day(date), Base: baseCurrency, Currency: currency}); ok {
+			rates = append(rates, rate)
+		}
+	}
+
+	return rates, nil
+}
+
+func (c *CurrencyStorage) GetRange(ctx context.Context, baseCurrency internal.Currency, currency internal.Currency, from time.Time, to time.Time) ([]internal.CurrencyRate, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	dates := c.dates[currencyPair{base: baseCurrency, currency: currency}]
+	i, _ := slices.BinarySearchFunc(dates, day(from), time.Time.Compare)
+
+	rates := make([]internal.CurrencyRate, 0)
+	for ; i < len(dates) && !dates[i].After(day(to)); i++ {
+		if rate, ok := c.current(ctx, internal.CurrencyRateKey{Date: dates[i], Base: baseCurrency, Currency: currency}); ok {
+			rates = append(rates, rate)
+		}
+	}
+
+	return rates, nil
+}
+
+func (c *CurrencyStorage) GetKeys(ctx context.Context, from time.Time, to time.Time) ([]internal.CurrencyRateKey, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	keys := make([]internal.CurrencyRateKey, 0)
+	for key := range c.revisions {
+		if !key.Date.Before(day(from)) && !key.Date.After(day(to)) {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys, nil
+}
+
+func (c *CurrencyStorage) GetRevisions(ctx context.Context, baseCurrency internal.Currency, currency internal.Currency, date time.Time) ([]internal.CurrencyRateRevision, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return slices.Clone(c.revisions[internal.CurrencyRateKey{Date: day(date), Base: baseCurrency, Currency: currency}]), nil
+}
+
+func (c *CurrencyStorage) Set(ctx context.Context, rate internal.CurrencyRate) error {
+	return c.SetMany(ctx, []internal.CurrencyRate{rate})
+}
+
+func (c *CurrencyStorage) SetMany(ctx context.Context, rates []internal.CurrencyRate) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	recordedAt := time.Now().UTC()
+
+	for _, rate := range rates {
+		rate.Date = day(rate.Date)
+		key := internal.CurrencyRateKey{Date: rate.Date, Base: rate.Base, Currency: rate.Currency}
+
+		revisions := c.revisions[key]
+		if len(revisions) > 0 && revisions[len(revisions)-1].CurrencyRate.Equal(rate) {
+			continue
+		}
+
+		if len(revisions) == 0 {
+			c.index(key)
+		}
+
+		c.revisions[key] = append(revisions, internal.CurrencyRateRevision{CurrencyRate: rate, RecordedAt: recordedAt})
+	}
+
+	return nil
+}
+
+func (c *CurrencyStorage) index(key internal.CurrencyRateKey) {
+	pair := currencyPair{base: key.Base, currency: key.Currency}
+
+	dates := c.dates[pair]
+	if len(dates) == 0 {
+		currencies := c.currencies[key.Base]
+		i, _ := slices.BinarySearch(currencies, key.Currency)
+		c.currencies[key.Base] = slices.Insert(currencies, i, key.Currency)
+	}
+
+	i, _ := slices.BinarySearchFunc(dates, key.Date, time.Time.Compare)
+	c.dates[pair] = slices.Insert(dates, i, key.Date)
+}
+
+func (c *CurrencyStorage) latest(ctx context.Context, baseCurrency internal.Currency, currency internal.Currency, from time.Time, to time.Time) (internal.CurrencyRate, bool) {
+	dates := c.dates[currencyPair{base: baseCurrency, currency: currency}]
+	i, found := slices.BinarySearchFunc(dates, to, time.Time.Compare)
+	if found {
+		i++
+	}
+
+	for i--; i >= 0 && !dates[i].Before(from); i-- {
+		if rate, ok := c.current(ctx, internal.CurrencyRateKey{Date: dates[i], Base: baseCurrency, Currency: currency}); ok {
+			return rate, true
+		}
+	}
+
+	return internal.CurrencyRate{}, false
+}
+
+func (c *CurrencyStorage) current(ctx context.Context, key internal.CurrencyRateKey) (internal.CurrencyRate, bool) {
+	revisions := c.revisions[key]
+
+	asKnownAt, ok := internal.AsKnownAt(ctx)
+	for i := len(revisions) - 1; i >= 0; i-- {
+		if !ok || !revisions[i].RecordedAt.After(asKnownAt) {
+			return revisions[i].CurrencyRate, true
+		}
+	}
+
+	return internal.CurrencyRate{}, false
+}
+
+func day(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
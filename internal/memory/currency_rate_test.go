@@ -0,0 +1,180 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fedorov-dmitry/go-test-api/internal"
+	"github.com/fedorov-dmitry/go-test-api/internal/memory"
+	"github.com/shopspring/decimal"
+)
+
+func dec(value string) decimal.Decimal {
+	return decimal.RequireFromString(value)
+}
+
+var (
+	usd = internal.NewCurrency("usd")
+	eur = internal.NewCurrency("eur")
+	jpy = internal.NewCurrency("jpy")
+)
+
+func day(d int) time.Time {
+	return time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestCurrencyStorage_GetLatestOnOrBefore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := memory.NewCurrencyStorage()
+
+	err := s.SetMany(ctx, []internal.CurrencyRate{
+		{Date: day(10), Base: usd, Currency: eur, Rate: dec("0.91")},
+		{Date: day(13), Base: usd, Currency: eur, Rate: dec("0.92")},
+		{Date: day(15), Base: usd, Currency: eur, Rate: dec("0.93")},
+		{Date: day(14), Base: usd, Currency: jpy, Rate: dec("150")},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := s.GetLatest(ctx, usd, eur, time.Date(2025, 1, 14, 18, 30, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !got.Date.Equal(day(13)) || !got.Rate.Equal(dec("0.92")) {
+		t.Fatalf("got %+v, want the rate from 2025-01-13", got)
+	}
+
+	_, err = s.GetLatest(ctx, usd, eur, day(9))
+	if !errors.Is(err, internal.ErrRateNotFound) {
+		t.Fatalf("expected ErrRateNotFound, got %v", err)
+	}
+
+	rates, err := s.GetLatestMany(ctx, usd, day(11), day(14))
+	if err != nil || len(rates) != 2 || rates[0].Currency != eur || !rates[0].Date.Equal(day(13)) || rates[1].Currency != jpy {
+		t.Fatalf("got %+v, %v, want the latest eur and jpy rates up to 2025-01-14", rates, err)
+	}
+}
+
+func TestCurrencyStorage_GetRangeAndMany(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := memory.NewCurrencyStorage()
+
+	for _, rate := range []internal.CurrencyRate{
+		{Date: day(15), Base: usd, Currency: eur, Rate: dec("0.93")},
+		{Date: day(12), Base: usd, Currency: eur, Rate: dec("0.90")},
+		{Date: day(13), Base: usd, Currency: eur, Rate: dec("0.92")},
+		{Date: day(13), Base: usd, Currency: jpy, Rate: dec("150")},
+		{Date: day(13), Base: eur, Currency: usd, Rate: dec("1.08")},
+	} {
+		if err := s.Set(ctx, rate); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	rates, err := s.GetRange(ctx, usd, eur, day(12), day(13))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rates) != 2 || !rates[0].Date.Equal(day(12)) || !rates[1].Date.Equal(day(13)) {
+		t.Fatalf("unexpected range: %+v", rates)
+	}
+
+	rates, err = s.GetMany(ctx, usd, day(13))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rates) != 2 || rates[0].Currency != eur || rates[1].Currency != jpy {
+		t.Fatalf("unexpected rates: %+v", rates)
+	}
+
+	keys, err := s.GetKeys(ctx, day(13), day(14))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(keys) != 3 {
+		t.Fatalf("got %d keys, want 3: %+v", len(keys), keys)
+	}
+}
+
+func TestCurrencyStorage_RevisionsAndAsKnownAt(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := memory.NewCurrencyStorage()
+
+	first := internal.CurrencyRate{Date: day(13), Base: usd, Currency: eur, Rate: dec("0.92")}
+	if err := s.Set(ctx, first); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Identical re-sync does not add a revision
+	if err := s.Set(ctx, first); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	time.Sleep(time.Millisecond)
+	between := time.Now().UTC()
+	time.Sleep(time.Millisecond)
+
+	if err := s.Set(ctx, internal.CurrencyRate{Date: day(13), Base: usd, Currency: eur, Rate: dec("0.95")}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	revisions, err := s.GetRevisions(ctx, usd, eur, day(13))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(revisions) != 2 || !revisions[0].Rate.Equal(dec("0.92")) || !revisions[1].Rate.Equal(dec("0.95")) {
+		t.Fatalf("unexpected revisions: %+v", revisions)
+	}
+
+	got, err := s.Get(ctx, usd, eur, day(13))
+	if err != nil || !got.Rate.Equal(dec("0.95")) {
+		t.Fatalf("got %+v, %v, want current rate 0.95", got, err)
+	}
+
+	got, err = s.Get(internal.WithAsKnownAt(ctx, between), usd, eur, day(13))
+	if err != nil || !got.Rate.Equal(dec("0.92")) {
+		t.Fatalf("got %+v, %v, want rate known at the time 0.92", got, err)
+	}
+
+	_, err = s.Get(internal.WithAsKnownAt(ctx, revisions[0].RecordedAt.Add(-time.Second)), usd, eur, day(13))
+	if !errors.Is(err, internal.ErrRateNotFound) {
+		t.Fatalf("expected ErrRateNotFound before the first revision, got %v", err)
+	}
+}
+
+func TestCurrencyStorage_GetLatestSkipsDatesRecordedLater(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := memory.NewCurrencyStorage()
+
+	if err := s.Set(ctx, internal.CurrencyRate{Date: day(13), Base: usd, Currency: eur, Rate: dec("0.92")}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	time.Sleep(time.Millisecond)
+	between := time.Now().UTC()
+	time.Sleep(time.Millisecond)
+
+	if err := s.Set(ctx, internal.CurrencyRate{Date: day(14), Base: usd, Currency: eur, Rate: dec("0.93")}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := s.GetLatest(internal.WithAsKnownAt(ctx, between), usd, eur, day(15))
+	if err != nil || !got.Date.Equal(day(13)) {
+		t.Fatalf("got %+v, %v, want the rate from 2025-01-13", got, err)
+	}
+
+	rates, err := s.GetRange(internal.WithAsKnownAt(ctx, between), usd, eur, day(1), day(31))
+	if err != nil || len(rates) != 1 {
+		t.Fatalf("got %+v, %v, want only the rate from 2025-01-13", rates, err)
+	}
+}
@@ -0,0 +1,131 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/fedorov-dmitry/go-test-api/internal"
+)
+
+type QuarantineStorage struct {
+	mu       sync.RWMutex
+	rates    []internal.QuarantinedRate
+	nextID   int64
+	currency *CurrencyStorage
+}
+
+func NewQuarantineStorage(currency *CurrencyStorage) *QuarantineStorage {
+	return &QuarantineStorage{rates: make([]internal.QuarantinedRate, 0), nextID: 1, currency: currency}
+}
+
+func (s *QuarantineStorage) Create(ctx context.Context, rate internal.QuarantinedRate) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rate.Rate.Date = day(rate.Rate.Date)
+
+	for i, existing := range s.rates {
+		if existing.Status == internal.QuarantineStatusPending &&
+			existing.Rate.Date.Equal(rate.Rate.Date) && existing.Rate.Base == rate.Rate.Base && existing.Rate.Currency == rate.Rate.Currency {
+			s.rates[i].Rate = rate.Rate
+			s.rates[i].PreviousDate = rate.PreviousDate
+			s.rates[i].PreviousRate = rate.PreviousRate
+			s.rates[i].Change = rate.Change
+
+			return existing.ID, nil
+		}
+	}
+
+	rate.ID = s.nextID
+	s.nextID++
+
+	s.rates = append(s.rates, rate)
+
+	return rate.ID, nil
+}
+
+func (s *QuarantineStorage) Get(ctx context.Context, id int64) (internal.QuarantinedRate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, rate := range s.rates {
+		if rate.ID == id {
+			return rate, nil
+		}
+	}
+
+	return internal.QuarantinedRate{}, fmt.Errorf("failed to get quarantined rate %d: %w", id, internal.ErrQuarantinedRateNotFound)
+}
+
+func (s *QuarantineStorage) List(ctx context.Context, status internal.QuarantineStatus, limit int) ([]internal.QuarantinedRate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rates := make([]internal.QuarantinedRate, 0)
+	for _, rate := range s.rates {
+		if status == "" || rate.Status == status {
+			rates = append(rates, rate)
+		}
+	}
+
+	slices.SortFunc(rates, func(a, b internal.QuarantinedRate) int {
+		return cmp.Or(b.CreatedAt.Compare(a.CreatedAt), cmp.Compare(b.ID, a.ID))
+	})
+
+	return rates[:min(limit, len(rates))], nil
+}
+
+func (s *QuarantineStorage) Update(ctx context.Context, rate internal.QuarantinedRate) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.rates {
+		if s.rates[i].ID == rate.ID {
+			s.rates[i].Status = rate.Status
+			s.rates[i].ResolvedAt = rate.ResolvedAt
+		}
+	}
+
+	return nil
+}
+
+func (s *QuarantineStorage) GetRejected(ctx context.Context, rate internal.CurrencyRate) (internal.QuarantinedRate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	date := day(rate.Date)
+	for i := len(s.rates) - 1; i >= 0; i-- {
+		existing := s.rates[i]
+		if existing.Status == internal.QuarantineStatusRejected &&
+			existing.Rate.Date.Equal(date) && existing.Rate.Base == rate.Base && existing.Rate.Currency == rate.Currency && existing.Rate.Rate.Equal(rate.Rate) {
+			return existing, nil
+		}
+	}
+
+	return internal.QuarantinedRate{}, fmt.Errorf("failed to get rejected rate for %s-%s: %w", rate.Base, rate.Currency, internal.ErrQuarantinedRateNotFound)
+}
+
+func (s *QuarantineStorage) Approve(ctx context.Context, rate internal.QuarantinedRate) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := slices.IndexFunc(s.rates, func(existing internal.QuarantinedRate) bool {
+		return existing.ID == rate.ID && existing.Status == internal.QuarantineStatusPending
+	})
+	if i < 0 {
+		return fmt.Errorf("failed to approve quarantined rate %d: %w", rate.ID, internal.ErrQuarantinedRateResolved)
+	}
+
+	err := s.currency.Set(ctx, rate.Rate)
+	if err != nil {
+		return fmt.Errorf("failed to approve quarantined rate %d: %w", rate.ID, err)
+	}
+
+	s.rates[i].Status = rate.Status
+	s.rates[i].ResolvedAt = rate.ResolvedAt
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fedorov-dmitry/go-test-api/internal"
+	"github.com/fedorov-dmitry/go-test-api/internal/memory"
+)
+
+func TestQuarantineStorage_ReplacesPendingForSameRate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := memory.NewQuarantineStorage(memory.NewCurrencyStorage())
+
+	rate := internal.QuarantinedRate{
+		Rate:      internal.CurrencyRate{Date: day(13), Base: usd, Currency: eur, Rate: dec("9.2")},
+		Status:    internal.QuarantineStatusPending,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	first, err := s.Create(ctx, rate)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rate.Rate.Rate = dec("9.3")
+	second, err := s.Create(ctx, rate)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first != second {
+		t.Fatalf("got ids %d and %d, want the pending entry to be replaced", first, second)
+	}
+
+	got, err := s.Get(ctx, first)
+	if err != nil || !got.Rate.Rate.Equal(dec("9.3")) {
+		t.Fatalf("got %+v, %v", got, err)
+	}
+
+	got.Status = internal.QuarantineStatusRejected
+	if err := s.Update(ctx, got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	third, err := s.Create(ctx, rate)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if third == first {
+		t.Fatal("expected a new entry once the previous one was resolved")
+	}
+
+	pending, err := s.List(ctx, internal.QuarantineStatusPending, 10)
+	if err != nil || len(pending) != 1 || pending[0].ID != third {
+		t.Fatalf("got %+v, %v", pending, err)
+	}
+
+	_, err = s.Get(ctx, 42)
+	if !errors.Is(err, internal.ErrQuarantinedRateNotFound) {
+		t.Fatalf("expected ErrQuarantinedRateNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/fedorov-dmitry/go-test-api/internal/middleware"
+)
+
+const maxRequestLogEntries = 10000
+
+type RequestLogStorage struct {
+	mu      sync.RWMutex
+	entries []middleware.RequestLog
+	next    int
+}
+
+func NewRequestLogStorage() *RequestLogStorage {
+	return &RequestLogStorage{entries: make([]middleware.RequestLog, 0)}
+}
+
+func (s *RequestLogStorage) Add(ctx context.Context, entry middleware.RequestLog) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.entries) < maxRequestLogEntries {
+		s.entries = append(s.entries, entry)
+		return nil
+	}
+
+	s.entries[s.next] = entry
+	s.next = (s.next + 1) % len(s.entries)
+
+	return nil
+}
+
+func (s *RequestLogStorage) List() []middleware.RequestLog {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries := make([]middleware.RequestLog, 0, len(s.entries))
+	entries = append(entries, s.entries[s.next:]...)
+	entries = append(entries, s.entries[:s.next]...)
+
+	return entries
+}
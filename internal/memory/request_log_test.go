@@ -0,0 +1,91 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fedorov-dmitry/go-test-api/internal"
+	"github.com/fedorov-dmitry/go-test-api/internal/memory"
+	"github.com/fedorov-dmitry/go-test-api/internal/middleware"
+)
+
+func TestRequestLogStorage_KeepsMostRecentEntries(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := memory.NewRequestLogStorage()
+
+	const total = 10005
+	for i := range total {
+		err := s.Add(ctx, middleware.RequestLog{Path: fmt.Sprintf("/%d", i)})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	entries := s.List()
+	if len(entries) != 10000 {
+		t.Fatalf("expected 10000 entries, got %d", len(entries))
+	}
+	if entries[0].Path != "/5" || entries[len(entries)-1].Path != fmt.Sprintf("/%d", total-1) {
+		t.Fatalf("expected entries /5../%d in order, got %s..%s", total-1, entries[0].Path, entries[len(entries)-1].Path)
+	}
+}
+
+func TestSyncRunStorage_KeepsMostRecentRunsAndLastSucceeded(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := memory.NewSyncRunStorage()
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	finishedAt := start.Add(time.Minute)
+
+	_, err := s.Create(ctx, internal.SyncRun{
+		Trigger:    internal.SyncTriggerSchedule,
+		Status:     internal.SyncRunStatusSucceeded,
+		StartedAt:  start,
+		FinishedAt: &finishedAt,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i := range 1000 {
+		_, err = s.Create(ctx, internal.SyncRun{
+			Trigger:   internal.SyncTriggerSchedule,
+			Status:    internal.SyncRunStatusFailed,
+			StartedAt: start.Add(time.Duration(i+1) * time.Hour),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	runs, err := s.List(ctx, 2000)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(runs) != 1000 {
+		t.Fatalf("expected 1000 runs, got %d", len(runs))
+	}
+	if runs[len(runs)-1].ID != 2 {
+		t.Fatalf("expected oldest kept run to be 2, got %d", runs[len(runs)-1].ID)
+	}
+
+	last, err := s.GetLastSucceeded(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if last.ID != 1 {
+		t.Fatalf("expected evicted run 1 to stay the last succeeded run, got %d", last.ID)
+	}
+
+	_, err = memory.NewSyncRunStorage().GetLastSucceeded(ctx)
+	if !errors.Is(err, internal.ErrSyncRunNotFound) {
+		t.Fatalf("expected ErrSyncRunNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/fedorov-dmitry/go-test-api/internal"
+)
+
+const maxSyncRuns = 1000
+
+type SyncRunStorage struct {
+	mu            sync.RWMutex
+	runs          []internal.SyncRun
+	next          int
+	nextID        int64
+	lastSucceeded *internal.SyncRun
+}
+
+func NewSyncRunStorage() *SyncRunStorage {
+	return &SyncRunStorage{runs: make([]internal.SyncRun, 0), nextID: 1}
+}
+
+func (s *SyncRunStorage) Create(ctx context.Context, run internal.SyncRun) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	run.ID = s.nextID
+	s.nextID++
+
+	if len(s.runs) < maxSyncRuns {
+		s.runs = append(s.runs, cloneSyncRun(run))
+	} else {
+		s.runs[s.next] = cloneSyncRun(run)
+		s.next = (s.next + 1) % len(s.runs)
+	}
+
+	s.remember(run)
+
+	return run.ID, nil
+}
+
+func (s *SyncRunStorage) Update(ctx context.Context, run internal.SyncRun) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.runs {
+		if s.runs[i].ID == run.ID {
+			updated := cloneSyncRun(run)
+
+			s.runs[i].Status = updated.Status
+			s.runs[i].FinishedAt = updated.FinishedAt
+			s.runs[i].RowsWritten = updated.RowsWritten
+			s.runs[i].Errors = updated.Errors
+
+			s.remember(s.runs[i])
+		}
+	}
+
+	return nil
+}
+
+func (s *SyncRunStorage) List(ctx context.Context, limit int) ([]internal.SyncRun, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	runs := make([]internal.SyncRun, 0, len(s.runs))
+	for _, run := range s.runs {
+		runs = append(runs, cloneSyncRun(run))
+	}
+
+	slices.SortFunc(runs, func(a, b internal.SyncRun) int {
+		return cmp.Or(b.StartedAt.Compare(a.StartedAt), cmp.Compare(b.ID, a.ID))
+	})
+
+	return runs[:min(limit, len(runs))], nil
+}
+
+func (s *SyncRunStorage) GetLastSucceeded(ctx context.Context) (internal.SyncRun, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.lastSucceeded == nil {
+		return internal.SyncRun{}, fmt.Errorf("failed to get last successful sync run: %w", internal.ErrSyncRunNotFound)
+	}
+
+	return cloneSyncRun(*s.lastSucceeded), nil
+}
+
+func (s *SyncRunStorage) remember(run internal.SyncRun) {
+	if run.Status != internal.SyncRunStatusSucceeded || run.FinishedAt == nil {
+		return
+	}
+
+	last := s.lastSucceeded
+	if last == nil || run.FinishedAt.After(*last.FinishedAt) || run.FinishedAt.Equal(*last.FinishedAt) && run.ID > last.ID {
+		run = cloneSyncRun(run)
+		s.lastSucceeded = &run
+	}
+}
+
+func cloneSyncRun(run internal.SyncRun) internal.SyncRun {
+	run.Bases = slices.Clone(run.Bases)
+	run.Dates = slices.Clone(run.Dates)
+	run.Errors = slices.Clone(run.Errors)
+
+	if run.FinishedAt != nil {
+		finishedAt := *run.FinishedAt
+		run.FinishedAt = &finishedAt
+	}
+
+	return run
+}
@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/fedorov-dmitry/go-test-api/internal"
+)
+
+type TrackedCurrencyStorage struct {
+	mu         sync.RWMutex
+	currencies map[internal.Currency]internal.TrackedCurrency
+}
+
+func NewTrackedCurrencyStorage() *TrackedCurrencyStorage {
+	return &TrackedCurrencyStorage{currencies: make(map[internal.Currency]internal.TrackedCurrency)}
+}
+
+func (s *TrackedCurrencyStorage) List(ctx context.Context) ([]internal.TrackedCurrency, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	currencies := make([]internal.TrackedCurrency, 0, len(s.currencies))
+	for _, currency := range s.currencies {
+		currencies = append(currencies, currency)
+	}
+
+	slices.SortFunc(currencies, func(a, b internal.TrackedCurrency) int {
+		return cmp.Or(a.AddedAt.Compare(b.AddedAt), cmp.Compare(a.Currency, b.Currency))
+	})
+
+	return currencies, nil
+}
+
+func (s *TrackedCurrencyStorage) Add(ctx context.Context, currency internal.TrackedCurrency) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.currencies[currency.Currency]; ok {
+		return fmt.Errorf("failed to add tracked currency %s: %w", currency.Currency, internal.ErrCurrencyAlreadyTracked)
+	}
+
+	s.currencies[currency.Currency] = currency
+
+	return nil
+}
+
+func (s *TrackedCurrencyStorage) Remove(ctx context.Context, currency internal.Currency) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.currencies[currency]; !ok {
+		return fmt.Errorf("failed to remove tracked currency %s: %w", currency, internal.ErrCurrencyNotTracked)
+	}
+
+	delete(s.currencies, currency)
+
+	return nil
+}
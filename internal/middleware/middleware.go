@@ -1,16 +1,45 @@
 package middleware
 
 import (
+	"context"
+	"crypto/subtle"
+	"errors"
 	"net/http"
 	"time"
 )
 
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+)
+
+type Authorizer func(ctx context.Context, apiKey string) error
+
 func AuthorizationMiddleware(expectedAPIKey string, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		apiKey := r.Header.Get("Authorization")
-		if apiKey != expectedAPIKey {
+		if subtle.ConstantTimeCompare([]byte(apiKey), []byte(expectedAPIKey)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func KeyAuthorizationMiddleware(authorize Authorizer, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := authorize(r.Context(), r.Header.Get("Authorization"))
+		switch {
+		case errors.Is(err, ErrUnauthorized):
 			http.Error(w, "unauthorized", http.StatusUnauthorized)
 			return
+		case errors.Is(err, ErrForbidden):
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		case err != nil:
+			http.Error(w, "failed to authorize request", http.StatusInternalServerError)
+			return
 		}
 
 		next.ServeHTTP(w, r)
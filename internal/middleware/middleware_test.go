@@ -1,6 +1,9 @@
 package middleware
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -51,6 +54,49 @@ func TestAuthorizationMiddleware_Authorized(t *testing.T) {
 	}
 }
 
+func TestKeyAuthorizationMiddleware_MapsErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "authorized", err: nil, want: http.StatusOK},
+		{name: "unauthorized", err: fmt.Errorf("%w: api key revoked", ErrUnauthorized), want: http.StatusUnauthorized},
+		{name: "forbidden", err: fmt.Errorf("%w: missing scope", ErrForbidden), want: http.StatusForbidden},
+		{name: "storage error", err: errors.New("connection refused"), want: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var gotKey string
+			authorize := func(ctx context.Context, apiKey string) error {
+				gotKey = apiKey
+				return tt.err
+			}
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+			h := KeyAuthorizationMiddleware(authorize, next)
+
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/k", nil)
+			req.Header.Set("Authorization", "gta_secret")
+			h.ServeHTTP(rr, req)
+
+			if rr.Code != tt.want {
+				t.Fatalf("status %d, want %d", rr.Code, tt.want)
+			}
+			if gotKey != "gta_secret" {
+				t.Fatalf("authorizer got %q", gotKey)
+			}
+		})
+	}
+}
+
 func TestRequestLoggingMiddleware_PushesLog(t *testing.T) {
 	t.Parallel()
 
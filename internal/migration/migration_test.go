@@ -0,0 +1,58 @@
+package migration
+
+import (
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestLoad_OrdersByVersion(t *testing.T) {
+	t.Parallel()
+
+	migrations, err := Load(fstest.MapFS{
+		"0010_second.up.sql":  {Data: []byte("CREATE TABLE b ();")},
+		"0002_first.up.sql":   {Data: []byte("CREATE TABLE a ();")},
+		"0002_first.down.sql": {Data: []byte("DROP TABLE a;")},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(migrations) != 2 || migrations[0].Version != 2 || migrations[1].Version != 10 {
+		t.Fatalf("unexpected migrations: %+v", migrations)
+	}
+	if migrations[0].Name != "first" || migrations[0].Down != "DROP TABLE a;" || migrations[1].Down != "" {
+		t.Fatalf("unexpected migrations: %+v", migrations)
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]fstest.MapFS{
+		"bad name":          {"first.sql": {Data: []byte("SELECT 1;")}},
+		"missing up":        {"0001_first.down.sql": {Data: []byte("SELECT 1;")}},
+		"duplicate version": {"0001_first.up.sql": {Data: []byte("SELECT 1;")}, "0001_other.up.sql": {Data: []byte("SELECT 1;")}},
+		"empty":             {},
+	}
+
+	for name, fsys := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Load(fsys)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
+
+func TestStatuses(t *testing.T) {
+	t.Parallel()
+
+	appliedAt := time.Date(2025, 1, 13, 10, 0, 0, 0, time.UTC)
+	statuses := Statuses([]Migration{{Version: 1, Name: "first"}, {Version: 2, Name: "second"}}, map[int]time.Time{1: appliedAt})
+
+	if len(statuses) != 2 || statuses[0].AppliedAt == nil || !statuses[0].AppliedAt.Equal(appliedAt) || statuses[1].AppliedAt != nil {
+		t.Fatalf("unexpected statuses: %+v", statuses)
+	}
+}
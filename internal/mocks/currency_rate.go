@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/fedorov-dmitry/go-test-api/internal (interfaces: CurrencyStorage,CurrencyRateSource)
+// Source: github.com/fedorov-dmitry/go-test-api/internal (interfaces: CurrencyStorage,CurrencyRateSource,SyncRunStorage,QuarantineStorage,CurrencyCatalogStorage,TrackedCurrencyStorage,ApiKeyStorage)
 //
 // Generated by this command:
 //
-//	mockgen -package=mocks -destination=/Users/dmitriy/Documents/Repo/go-test-api/internal/mocks/currency_rate.go github.com/fedorov-dmitry/go-test-api/internal CurrencyStorage,CurrencyRateSource
+//	mockgen -package=mocks -destination=/Users/dmitriy/Documents/Repo/go-test-api/internal/mocks/currency_rate.go github.com/fedorov-dmitry/go-test-api/internal CurrencyStorage,CurrencyRateSource,SyncRunStorage,QuarantineStorage,CurrencyCatalogStorage,TrackedCurrencyStorage,ApiKeyStorage
 //
 
 // Package mocks is a generated GoMock package.
@@ -57,6 +57,51 @@ func (mr *MockCurrencyStorageMockRecorder) Get(ctx, baseCurrency, currency, date
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCurrencyStorage)(nil).Get), ctx, baseCurrency, currency, date)
 }
 
+// GetKeys mocks base method.
+func (m *MockCurrencyStorage) GetKeys(ctx context.Context, from, to time.Time) ([]internal.CurrencyRateKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKeys", ctx, from, to)
+	ret0, _ := ret[0].([]internal.CurrencyRateKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKeys indicates an expected call of GetKeys.
+func (mr *MockCurrencyStorageMockRecorder) GetKeys(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeys", reflect.TypeOf((*MockCurrencyStorage)(nil).GetKeys), ctx, from, to)
+}
+
+// GetLatest mocks base method.
+func (m *MockCurrencyStorage) GetLatest(ctx context.Context, baseCurrency, currency internal.Currency, date time.Time) (internal.CurrencyRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatest", ctx, baseCurrency, currency, date)
+	ret0, _ := ret[0].(internal.CurrencyRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatest indicates an expected call of GetLatest.
+func (mr *MockCurrencyStorageMockRecorder) GetLatest(ctx, baseCurrency, currency, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatest", reflect.TypeOf((*MockCurrencyStorage)(nil).GetLatest), ctx, baseCurrency, currency, date)
+}
+
+// GetLatestMany mocks base method.
+func (m *MockCurrencyStorage) GetLatestMany(ctx context.Context, baseCurrency internal.Currency, from, to time.Time) ([]internal.CurrencyRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestMany", ctx, baseCurrency, from, to)
+	ret0, _ := ret[0].([]internal.CurrencyRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestMany indicates an expected call of GetLatestMany.
+func (mr *MockCurrencyStorageMockRecorder) GetLatestMany(ctx, baseCurrency, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestMany", reflect.TypeOf((*MockCurrencyStorage)(nil).GetLatestMany), ctx, baseCurrency, from, to)
+}
+
 // GetMany mocks base method.
 func (m *MockCurrencyStorage) GetMany(ctx context.Context, baseCurrency internal.Currency, date time.Time) ([]internal.CurrencyRate, error) {
 	m.ctrl.T.Helper()
@@ -72,6 +117,36 @@ func (mr *MockCurrencyStorageMockRecorder) GetMany(ctx, baseCurrency, date any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMany", reflect.TypeOf((*MockCurrencyStorage)(nil).GetMany), ctx, baseCurrency, date)
 }
 
+// GetRange mocks base method.
+func (m *MockCurrencyStorage) GetRange(ctx context.Context, baseCurrency, currency internal.Currency, from, to time.Time) ([]internal.CurrencyRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRange", ctx, baseCurrency, currency, from, to)
+	ret0, _ := ret[0].([]internal.CurrencyRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRange indicates an expected call of GetRange.
+func (mr *MockCurrencyStorageMockRecorder) GetRange(ctx, baseCurrency, currency, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRange", reflect.TypeOf((*MockCurrencyStorage)(nil).GetRange), ctx, baseCurrency, currency, from, to)
+}
+
+// GetRevisions mocks base method.
+func (m *MockCurrencyStorage) GetRevisions(ctx context.Context, baseCurrency, currency internal.Currency, date time.Time) ([]internal.CurrencyRateRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevisions", ctx, baseCurrency, currency, date)
+	ret0, _ := ret[0].([]internal.CurrencyRateRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevisions indicates an expected call of GetRevisions.
+func (mr *MockCurrencyStorageMockRecorder) GetRevisions(ctx, baseCurrency, currency, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevisions", reflect.TypeOf((*MockCurrencyStorage)(nil).GetRevisions), ctx, baseCurrency, currency, date)
+}
+
 // Set mocks base method.
 func (m *MockCurrencyStorage) Set(ctx context.Context, currency internal.CurrencyRate) error {
 	m.ctrl.T.Helper()
@@ -86,6 +161,20 @@ func (mr *MockCurrencyStorageMockRecorder) Set(ctx, currency any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockCurrencyStorage)(nil).Set), ctx, currency)
 }
 
+// SetMany mocks base method.
+func (m *MockCurrencyStorage) SetMany(ctx context.Context, rates []internal.CurrencyRate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMany", ctx, rates)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMany indicates an expected call of SetMany.
+func (mr *MockCurrencyStorageMockRecorder) SetMany(ctx, rates any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMany", reflect.TypeOf((*MockCurrencyStorage)(nil).SetMany), ctx, rates)
+}
+
 // MockCurrencyRateSource is a mock of CurrencyRateSource interface.
 type MockCurrencyRateSource struct {
 	ctrl     *gomock.Controller
@@ -111,16 +200,429 @@ func (m *MockCurrencyRateSource) EXPECT() *MockCurrencyRateSourceMockRecorder {
 }
 
 // Get mocks base method.
-func (m *MockCurrencyRateSource) Get(baseCurrency internal.Currency, currencies []internal.Currency, date time.Time) ([]internal.CurrencyRate, error) {
+func (m *MockCurrencyRateSource) Get(ctx context.Context, baseCurrency internal.Currency, currencies []internal.Currency, date time.Time) ([]internal.CurrencyRate, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", baseCurrency, currencies, date)
+	ret := m.ctrl.Call(m, "Get", ctx, baseCurrency, currencies, date)
 	ret0, _ := ret[0].([]internal.CurrencyRate)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockCurrencyRateSourceMockRecorder) Get(baseCurrency, currencies, date any) *gomock.Call {
+func (mr *MockCurrencyRateSourceMockRecorder) Get(ctx, baseCurrency, currencies, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCurrencyRateSource)(nil).Get), ctx, baseCurrency, currencies, date)
+}
+
+// MockSyncRunStorage is a mock of SyncRunStorage interface.
+type MockSyncRunStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockSyncRunStorageMockRecorder
+	isgomock struct{}
+}
+
+// MockSyncRunStorageMockRecorder is the mock recorder for MockSyncRunStorage.
+type MockSyncRunStorageMockRecorder struct {
+	mock *MockSyncRunStorage
+}
+
+// NewMockSyncRunStorage creates a new mock instance.
+func NewMockSyncRunStorage(ctrl *gomock.Controller) *MockSyncRunStorage {
+	mock := &MockSyncRunStorage{ctrl: ctrl}
+	mock.recorder = &MockSyncRunStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSyncRunStorage) EXPECT() *MockSyncRunStorageMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSyncRunStorage) Create(ctx context.Context, run internal.SyncRun) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, run)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSyncRunStorageMockRecorder) Create(ctx, run any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSyncRunStorage)(nil).Create), ctx, run)
+}
+
+// GetLastSucceeded mocks base method.
+func (m *MockSyncRunStorage) GetLastSucceeded(ctx context.Context) (internal.SyncRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastSucceeded", ctx)
+	ret0, _ := ret[0].(internal.SyncRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastSucceeded indicates an expected call of GetLastSucceeded.
+func (mr *MockSyncRunStorageMockRecorder) GetLastSucceeded(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastSucceeded", reflect.TypeOf((*MockSyncRunStorage)(nil).GetLastSucceeded), ctx)
+}
+
+// List mocks base method.
+func (m *MockSyncRunStorage) List(ctx context.Context, limit int) ([]internal.SyncRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, limit)
+	ret0, _ := ret[0].([]internal.SyncRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockSyncRunStorageMockRecorder) List(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockSyncRunStorage)(nil).List), ctx, limit)
+}
+
+// Update mocks base method.
+func (m *MockSyncRunStorage) Update(ctx context.Context, run internal.SyncRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, run)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSyncRunStorageMockRecorder) Update(ctx, run any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSyncRunStorage)(nil).Update), ctx, run)
+}
+
+// MockQuarantineStorage is a mock of QuarantineStorage interface.
+type MockQuarantineStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuarantineStorageMockRecorder
+	isgomock struct{}
+}
+
+// MockQuarantineStorageMockRecorder is the mock recorder for MockQuarantineStorage.
+type MockQuarantineStorageMockRecorder struct {
+	mock *MockQuarantineStorage
+}
+
+// NewMockQuarantineStorage creates a new mock instance.
+func NewMockQuarantineStorage(ctrl *gomock.Controller) *MockQuarantineStorage {
+	mock := &MockQuarantineStorage{ctrl: ctrl}
+	mock.recorder = &MockQuarantineStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQuarantineStorage) EXPECT() *MockQuarantineStorageMockRecorder {
+	return m.recorder
+}
+
+// Approve mocks base method.
+func (m *MockQuarantineStorage) Approve(ctx context.Context, rate internal.QuarantinedRate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Approve", ctx, rate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Approve indicates an expected call of Approve.
+func (mr *MockQuarantineStorageMockRecorder) Approve(ctx, rate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockQuarantineStorage)(nil).Approve), ctx, rate)
+}
+
+// Create mocks base method.
+func (m *MockQuarantineStorage) Create(ctx context.Context, rate internal.QuarantinedRate) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, rate)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockQuarantineStorageMockRecorder) Create(ctx, rate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockQuarantineStorage)(nil).Create), ctx, rate)
+}
+
+// Get mocks base method.
+func (m *MockQuarantineStorage) Get(ctx context.Context, id int64) (internal.QuarantinedRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(internal.QuarantinedRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockQuarantineStorageMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockQuarantineStorage)(nil).Get), ctx, id)
+}
+
+// GetRejected mocks base method.
+func (m *MockQuarantineStorage) GetRejected(ctx context.Context, rate internal.CurrencyRate) (internal.QuarantinedRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRejected", ctx, rate)
+	ret0, _ := ret[0].(internal.QuarantinedRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRejected indicates an expected call of GetRejected.
+func (mr *MockQuarantineStorageMockRecorder) GetRejected(ctx, rate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRejected", reflect.TypeOf((*MockQuarantineStorage)(nil).GetRejected), ctx, rate)
+}
+
+// List mocks base method.
+func (m *MockQuarantineStorage) List(ctx context.Context, status internal.QuarantineStatus, limit int) ([]internal.QuarantinedRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, status, limit)
+	ret0, _ := ret[0].([]internal.QuarantinedRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockQuarantineStorageMockRecorder) List(ctx, status, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockQuarantineStorage)(nil).List), ctx, status, limit)
+}
+
+// Update mocks base method.
+func (m *MockQuarantineStorage) Update(ctx context.Context, rate internal.QuarantinedRate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, rate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockQuarantineStorageMockRecorder) Update(ctx, rate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockQuarantineStorage)(nil).Update), ctx, rate)
+}
+
+// MockCurrencyCatalogStorage is a mock of CurrencyCatalogStorage interface.
+type MockCurrencyCatalogStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockCurrencyCatalogStorageMockRecorder
+	isgomock struct{}
+}
+
+// MockCurrencyCatalogStorageMockRecorder is the mock recorder for MockCurrencyCatalogStorage.
+type MockCurrencyCatalogStorageMockRecorder struct {
+	mock *MockCurrencyCatalogStorage
+}
+
+// NewMockCurrencyCatalogStorage creates a new mock instance.
+func NewMockCurrencyCatalogStorage(ctrl *gomock.Controller) *MockCurrencyCatalogStorage {
+	mock := &MockCurrencyCatalogStorage{ctrl: ctrl}
+	mock.recorder = &MockCurrencyCatalogStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCurrencyCatalogStorage) EXPECT() *MockCurrencyCatalogStorageMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockCurrencyCatalogStorage) List(ctx context.Context) ([]internal.CurrencyInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]internal.CurrencyInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockCurrencyCatalogStorageMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCurrencyCatalogStorage)(nil).List), ctx)
+}
+
+// Seed mocks base method.
+func (m *MockCurrencyCatalogStorage) Seed(ctx context.Context, currencies []internal.CurrencyInfo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Seed", ctx, currencies)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Seed indicates an expected call of Seed.
+func (mr *MockCurrencyCatalogStorageMockRecorder) Seed(ctx, currencies any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Seed", reflect.TypeOf((*MockCurrencyCatalogStorage)(nil).Seed), ctx, currencies)
+}
+
+// MockTrackedCurrencyStorage is a mock of TrackedCurrencyStorage interface.
+type MockTrackedCurrencyStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrackedCurrencyStorageMockRecorder
+	isgomock struct{}
+}
+
+// MockTrackedCurrencyStorageMockRecorder is the mock recorder for MockTrackedCurrencyStorage.
+type MockTrackedCurrencyStorageMockRecorder struct {
+	mock *MockTrackedCurrencyStorage
+}
+
+// NewMockTrackedCurrencyStorage creates a new mock instance.
+func NewMockTrackedCurrencyStorage(ctrl *gomock.Controller) *MockTrackedCurrencyStorage {
+	mock := &MockTrackedCurrencyStorage{ctrl: ctrl}
+	mock.recorder = &MockTrackedCurrencyStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTrackedCurrencyStorage) EXPECT() *MockTrackedCurrencyStorageMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockTrackedCurrencyStorage) Add(ctx context.Context, currency internal.TrackedCurrency) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, currency)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockTrackedCurrencyStorageMockRecorder) Add(ctx, currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockTrackedCurrencyStorage)(nil).Add), ctx, currency)
+}
+
+// List mocks base method.
+func (m *MockTrackedCurrencyStorage) List(ctx context.Context) ([]internal.TrackedCurrency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]internal.TrackedCurrency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockTrackedCurrencyStorageMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTrackedCurrencyStorage)(nil).List), ctx)
+}
+
+// Remove mocks base method.
+func (m *MockTrackedCurrencyStorage) Remove(ctx context.Context, currency internal.Currency) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", ctx, currency)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockTrackedCurrencyStorageMockRecorder) Remove(ctx, currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockTrackedCurrencyStorage)(nil).Remove), ctx, currency)
+}
+
+// MockApiKeyStorage is a mock of ApiKeyStorage interface.
+type MockApiKeyStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockApiKeyStorageMockRecorder
+	isgomock struct{}
+}
+
+// MockApiKeyStorageMockRecorder is the mock recorder for MockApiKeyStorage.
+type MockApiKeyStorageMockRecorder struct {
+	mock *MockApiKeyStorage
+}
+
+// NewMockApiKeyStorage creates a new mock instance.
+func NewMockApiKeyStorage(ctrl *gomock.Controller) *MockApiKeyStorage {
+	mock := &MockApiKeyStorage{ctrl: ctrl}
+	mock.recorder = &MockApiKeyStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApiKeyStorage) EXPECT() *MockApiKeyStorageMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockApiKeyStorage) Create(ctx context.Context, key internal.ApiKey) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, key)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockApiKeyStorageMockRecorder) Create(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockApiKeyStorage)(nil).Create), ctx, key)
+}
+
+// Get mocks base method.
+func (m *MockApiKeyStorage) Get(ctx context.Context, id int64) (internal.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(internal.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockApiKeyStorageMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockApiKeyStorage)(nil).Get), ctx, id)
+}
+
+// GetByHash mocks base method.
+func (m *MockApiKeyStorage) GetByHash(ctx context.Context, hash string) (internal.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByHash", ctx, hash)
+	ret0, _ := ret[0].(internal.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByHash indicates an expected call of GetByHash.
+func (mr *MockApiKeyStorageMockRecorder) GetByHash(ctx, hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHash", reflect.TypeOf((*MockApiKeyStorage)(nil).GetByHash), ctx, hash)
+}
+
+// List mocks base method.
+func (m *MockApiKeyStorage) List(ctx context.Context) ([]internal.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]internal.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockApiKeyStorageMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockApiKeyStorage)(nil).List), ctx)
+}
+
+// Update mocks base method.
+func (m *MockApiKeyStorage) Update(ctx context.Context, key internal.ApiKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockApiKeyStorageMockRecorder) Update(ctx, key any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCurrencyRateSource)(nil).Get), baseCurrency, currencies, date)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockApiKeyStorage)(nil).Update), ctx, key)
 }
@@ -0,0 +1,141 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fedorov-dmitry/go-test-api/internal"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ApiKeyStorage struct {
+	pgPool *pgxpool.Pool
+}
+
+func NewApiKeyStorage(pgPool *pgxpool.Pool) *ApiKeyStorage {
+	return &ApiKeyStorage{pgPool: pgPool}
+}
+
+func (s *ApiKeyStorage) Create(ctx context.Context, key internal.ApiKey) (int64, error) {
+	sql := `
+INSERT INTO app.api_keys (name, prefix, key_hash, scopes, created_at, expires_at, revoked_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id`
+
+	var id int64
+
+	err := s.pgPool.QueryRow(ctx, sql, key.Name, key.Prefix, key.Hash, scopeStrings(key.Scopes), key.CreatedAt, key.ExpiresAt, key.RevokedAt).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return id, nil
+}
+
+func (s *ApiKeyStorage) Get(ctx context.Context, id int64) (internal.ApiKey, error) {
+	sql := `
+SELECT id, name, prefix, key_hash, scopes, created_at, expires_at, revoked_at FROM app.api_keys
+WHERE id = $1`
+
+	key, err := scanApiKey(s.pgPool.QueryRow(ctx, sql, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = internal.ErrApiKeyNotFound
+	}
+	if err != nil {
+		return internal.ApiKey{}, fmt.Errorf("failed to get api key %d: %w", id, err)
+	}
+
+	return key, nil
+}
+
+func (s *ApiKeyStorage) GetByHash(ctx context.Context, hash string) (internal.ApiKey, error) {
+	sql := `
+SELECT id, name, prefix, key_hash, scopes, created_at, expires_at, revoked_at FROM app.api_keys
+WHERE key_hash = $1`
+
+	key, err := scanApiKey(s.pgPool.QueryRow(ctx, sql, hash))
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = internal.ErrApiKeyNotFound
+	}
+	if err != nil {
+		return internal.ApiKey{}, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	return key, nil
+}
+
+func (s *ApiKeyStorage) List(ctx context.Context) ([]internal.ApiKey, error) {
+	sql := `
+SELECT id, name, prefix, key_hash, scopes, created_at, expires_at, revoked_at FROM app.api_keys
+ORDER BY id`
+
+	rows, err := s.pgPool.Query(ctx, sql)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch api keys: %w", err)
+	}
+
+	keys := make([]internal.ApiKey, 0)
+
+	defer rows.Close()
+
+	for rows.Next() {
+		key, err := scanApiKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch api keys: %w", err)
+		}
+
+		keys = append(keys, key)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+func (s *ApiKeyStorage) Update(ctx context.Context, key internal.ApiKey) error {
+	sql := `
+UPDATE app.api_keys
+SET prefix = $2,
+    key_hash = $3,
+    revoked_at = $4
+WHERE id = $1`
+
+	_, err := s.pgPool.Exec(ctx, sql, key.ID, key.Prefix, key.Hash, key.RevokedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update api key %d: %w", key.ID, err)
+	}
+
+	return nil
+}
+
+func scanApiKey(row pgx.Row) (internal.ApiKey, error) {
+	key := internal.ApiKey{}
+
+	var scopes []string
+
+	err := row.Scan(&key.ID, &key.Name, &key.Prefix, &key.Hash, &scopes, &key.CreatedAt, &key.ExpiresAt, &key.RevokedAt)
+	if err != nil {
+		return internal.ApiKey{}, err
+	}
+
+	key.Scopes = make([]internal.ApiKeyScope, len(scopes))
+	for i, scope := range scopes {
+		key.Scopes[i] = internal.ApiKeyScope(scope)
+	}
+
+	return key, nil
+}
+
+func scopeStrings(scopes []internal.ApiKeyScope) []string {
+	result := make([]string, len(scopes))
+	for i, scope := range scopes {
+		result[i] = string(scope)
+	}
+
+	return result
+}
@@ -0,0 +1,72 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fedorov-dmitry/go-test-api/internal"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CurrencyCatalogStorage struct {
+	pgPool *pgxpool.Pool
+}
+
+func NewCurrencyCatalogStorage(pgPool *pgxpool.Pool) *CurrencyCatalogStorage {
+	return &CurrencyCatalogStorage{pgPool: pgPool}
+}
+
+func (s *CurrencyCatalogStorage) List(ctx context.Context) ([]internal.CurrencyInfo, error) {
+	sql := `
+SELECT code, numeric_code, name, minor_units, active, kind FROM app.currencies
+ORDER BY code`
+
+	rows, err := s.pgPool.Query(ctx, sql)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch currencies: %w", err)
+	}
+
+	currencies := make([]internal.CurrencyInfo, 0)
+
+	defer rows.Close()
+
+	for rows.Next() {
+		currency := internal.CurrencyInfo{}
+
+		err = rows.Scan(&currency.Code, &currency.NumericCode, &currency.Name, &currency.MinorUnits, &currency.Active, &currency.Kind)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch currencies: %w", err)
+		}
+
+		currencies = append(currencies, currency)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch currencies: %w", err)
+	}
+
+	return currencies, nil
+}
+
+func (s *CurrencyCatalogStorage) Seed(ctx context.Context, currencies []internal.CurrencyInfo) error {
+	sql := `
+INSERT INTO app.currencies (code, numeric_code, name, minor_units, active, kind)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (code) DO NOTHING`
+
+	err := pgx.BeginFunc(ctx, s.pgPool, func(tx pgx.Tx) error {
+		batch := &pgx.Batch{}
+		for _, currency := range currencies {
+			batch.Queue(sql, currency.Code, currency.NumericCode, currency.Name, currency.MinorUnits, currency.Active, currency.Kind)
+		}
+
+		return tx.SendBatch(ctx, batch).Close()
+	})
+	if err != nil {
+		return fmt.Errorf("failed to seed %d currencies: %w", len(currencies), err)
+	}
+
+	return nil
+}
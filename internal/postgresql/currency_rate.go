@@ -2,10 +2,12 @@ package postgresql
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/fedorov-dmitry/go-test-api/internal"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -18,16 +20,20 @@ func NewCurrencyStorage(pgPool *pgxpool.Pool) *CurrencyStorage {
 }
 
 func (c *CurrencyStorage) Get(ctx context.Context, baseCurrency internal.Currency, currency internal.Currency, date time.Time) (internal.CurrencyRate, error) {
+	source, args := ratesSource(ctx, baseCurrency, currency, date.Format("2006-01-02"))
 	sql := `
-SELECT date, base, currency, rate FROM app.currency_rates
+SELECT date, base, currency, rate, derived, provider, spread FROM ` + source + `
 WHERE base = $1
   AND currency = $2
   AND date = $3`
 
-	res := c.pgPool.QueryRow(ctx, sql, baseCurrency, currency, date.Format("2006-01-02"))
+	res := c.pgPool.QueryRow(ctx, sql, args...)
 	rates := internal.CurrencyRate{}
 
-	err := res.Scan(&rates.Date, &rates.Base, &rates.Currency, &rates.Rate)
+	err := res.Scan(&rates.Date, &rates.Base, &rates.Currency, &rates.Rate, &rates.Derived, &rates.Provider, &rates.Spread)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = internal.ErrRateNotFound
+	}
 	if err != nil {
 		return internal.CurrencyRate{}, fmt.Errorf("failed to get currency rates for %s-%s: %w", baseCurrency, currency, err)
 	}
@@ -35,13 +41,75 @@ WHERE base = $1
 	return rates, nil
 }
 
+func (c *CurrencyStorage) GetLatest(ctx context.Context, baseCurrency internal.Currency, currency internal.Currency, date time.Time) (internal.CurrencyRate, error) {
+	source, args := ratesSource(ctx, baseCurrency, currency, date.Format("2006-01-02"))
+	sql := `
+SELECT date, base, currency, rate, derived, provider, spread FROM ` + source + `
+WHERE base = $1
+  AND currency = $2
+  AND date <= $3
+ORDER BY date DESC
+LIMIT 1`
+
+	res := c.pgPool.QueryRow(ctx, sql, args...)
+	rate := internal.CurrencyRate{}
+
+	err := res.Scan(&rate.Date, &rate.Base, &rate.Currency, &rate.Rate, &rate.Derived, &rate.Provider, &rate.Spread)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = internal.ErrRateNotFound
+	}
+	if err != nil {
+		return internal.CurrencyRate{}, fmt.Errorf("failed to get latest currency rate for %s-%s: %w", baseCurrency, currency, err)
+	}
+
+	return rate, nil
+}
+
+func (c *CurrencyStorage) GetLatestMany(ctx context.Context, baseCurrency internal.Currency, from time.Time, to time.Time) ([]internal.CurrencyRate, error) {
+	source, args := ratesSource(ctx, baseCurrency, from.Format("2006-01-02"), to.Format("2006-01-02"))
+	sql := `
+SELECT DISTINCT ON (currency) date, base, currency, rate, derived, provider, spread FROM ` + source + `
+WHERE base = $1
+  AND date >= $2
+  AND date <= $3
+ORDER BY currency, date DESC`
+
+	rows, err := c.pgPool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch latest currency rates for %s: %w", baseCurrency, err)
+	}
+
+	rates := make([]internal.CurrencyRate, 0)
+
+	defer rows.Close()
+
+	for rows.Next() {
+		rate := internal.CurrencyRate{}
+
+		err = rows.Scan(&rate.Date, &rate.Base, &rate.Currency, &rate.Rate, &rate.Derived, &rate.Provider, &rate.Spread)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch latest currency rates for %s: %w", baseCurrency, err)
+		}
+
+		rates = append(rates, rate)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch latest currency rates for %s: %w", baseCurrency, err)
+	}
+
+	return rates, nil
+}
+
 func (c *CurrencyStorage) GetMany(ctx context.Context, baseCurrency internal.Currency, date time.Time) ([]internal.CurrencyRate, error) {
+	source, args := ratesSource(ctx, baseCurrency, date.Format("2006-01-02"))
 	sql := `
-SELECT date, base, currency, rate FROM app.currency_rates
+SELECT date, base, currency, rate, derived, provider, spread FROM ` + source + `
 WHERE base = $1
 AND date = $2`
 
-	rows, err := c.pgPool.Query(ctx, sql, baseCurrency, date.Format("2006-01-02"))
+	rows, err := c.pgPool.Query(ctx, sql, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch currency rates for %s: %w", baseCurrency, err)
 	}
@@ -53,7 +121,7 @@ AND date = $2`
 	for rows.Next() {
 		rate := internal.CurrencyRate{}
 
-		err = rows.Scan(&rate.Date, &rate.Base, &rate.Currency, &rate.Rate)
+		err = rows.Scan(&rate.Date, &rate.Base, &rate.Currency, &rate.Rate, &rate.Derived, &rate.Provider, &rate.Spread)
 		if err != nil {
 			return nil, fmt.Errorf("failed to fetch currency rates for %s: %w", baseCurrency, err)
 		}
@@ -69,18 +137,175 @@ AND date = $2`
 	return rates, nil
 }
 
-func (c *CurrencyStorage) Set(ctx context.Context, rate internal.CurrencyRate) error {
+func (c *CurrencyStorage) GetRange(ctx context.Context, baseCurrency internal.Currency, currency internal.Currency, from time.Time, to time.Time) ([]internal.CurrencyRate, error) {
+	source, args := ratesSource(ctx, baseCurrency, currency, from.Format("2006-01-02"), to.Format("2006-01-02"))
 	sql := `
-INSERT INTO app.currency_rates
-VALUES ($1, $2, $3, $4)
-ON CONFLICT (date, base, currency)
-DO UPDATE SET
-   rate = EXCLUDED.rate;`
+SELECT date, base, currency, rate, derived, provider, spread FROM ` + source + `
+WHERE base = $1
+  AND currency = $2
+  AND date BETWEEN $3 AND $4
+ORDER BY date`
+
+	rows, err := c.pgPool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch currency rates for %s-%s: %w", baseCurrency, currency, err)
+	}
+
+	rates := make([]internal.CurrencyRate, 0)
+
+	defer rows.Close()
 
-	_, err := c.pgPool.Exec(ctx, sql, rate.Date, rate.Base, rate.Currency, rate.Rate)
+	for rows.Next() {
+		rate := internal.CurrencyRate{}
+
+		err = rows.Scan(&rate.Date, &rate.Base, &rate.Currency, &rate.Rate, &rate.Derived, &rate.Provider, &rate.Spread)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch currency rates for %s-%s: %w", baseCurrency, currency, err)
+		}
+
+		rates = append(rates, rate)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch currency rates for %s-%s: %w", baseCurrency, currency, err)
+	}
+
+	return rates, nil
+}
+
+func (c *CurrencyStorage) GetKeys(ctx context.Context, from time.Time, to time.Time) ([]internal.CurrencyRateKey, error) {
+	sql := `
+SELECT date, base, currency FROM app.currency_rates
+WHERE date BETWEEN $1 AND $2`
+
+	rows, err := c.pgPool.Query(ctx, sql, from.Format("2006-01-02"), to.Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch stored currency rates: %w", err)
+	}
+
+	keys := make([]internal.CurrencyRateKey, 0)
+
+	defer rows.Close()
+
+	for rows.Next() {
+		key := internal.CurrencyRateKey{}
+
+		err = rows.Scan(&key.Date, &key.Base, &key.Currency)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch stored currency rates: %w", err)
+		}
+
+		keys = append(keys, key)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch stored currency rates: %w", err)
+	}
+
+	return keys, nil
+}
+
+const setCurrencyRateSQL = `
+WITH changed AS (
+    INSERT INTO app.currency_rates (date, base, currency, rate, derived, provider, spread)
+    VALUES ($1, $2, $3, $4, $5, $6, $7)
+    ON CONFLICT (date, base, currency)
+    DO UPDATE SET
+       rate = EXCLUDED.rate,
+       derived = EXCLUDED.derived,
+       provider = EXCLUDED.provider,
+       spread = EXCLUDED.spread
+    WHERE (app.currency_rates.rate, app.currency_rates.derived, app.currency_rates.provider, app.currency_rates.spread)
+        IS DISTINCT FROM (EXCLUDED.rate, EXCLUDED.derived, EXCLUDED.provider, EXCLUDED.spread)
+    RETURNING date, base, currency, rate, derived, provider, spread
+)
+INSERT INTO app.currency_rate_revisions (date, base, currency, rate, derived, provider, spread, recorded_at)
+SELECT date, base, currency, rate, derived, provider, spread, now() FROM changed;`
+
+func (c *CurrencyStorage) Set(ctx context.Context, rate internal.CurrencyRate) error {
+	_, err := c.pgPool.Exec(ctx, setCurrencyRateSQL, rate.Date, rate.Base, rate.Currency, rate.Rate, rate.Derived, rate.Provider, rate.Spread)
 	if err != nil {
 		return fmt.Errorf("failed to save currency rate for %s-%s: %w", rate.Base, rate.Currency, err)
 	}
 
 	return nil
 }
+
+func (c *CurrencyStorage) SetMany(ctx context.Context, rates []internal.CurrencyRate) error {
+	err := pgx.BeginFunc(ctx, c.pgPool, func(tx pgx.Tx) error {
+		batch := &pgx.Batch{}
+		for _, rate := range rates {
+			batch.Queue(setCurrencyRateSQL, rate.Date, rate.Base, rate.Currency, rate.Rate, rate.Derived, rate.Provider, rate.Spread)
+		}
+
+		results := tx.SendBatch(ctx, batch)
+
+		for _, rate := range rates {
+			_, err := results.Exec()
+			if err != nil {
+				_ = results.Close()
+				return fmt.Errorf("failed to save currency rate for %s-%s: %w", rate.Base, rate.Currency, err)
+			}
+		}
+
+		return results.Close()
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save %d currency rates: %w", len(rates), err)
+	}
+
+	return nil
+}
+
+func (c *CurrencyStorage) GetRevisions(ctx context.Context, baseCurrency internal.Currency, currency internal.Currency, date time.Time) ([]internal.CurrencyRateRevision, error) {
+	sql := `
+SELECT date, base, currency, rate, derived, provider, spread, recorded_at FROM app.currency_rate_revisions
+WHERE base = $1
+  AND currency = $2
+  AND date = $3
+ORDER BY recorded_at`
+
+	rows, err := c.pgPool.Query(ctx, sql, baseCurrency, currency, date.Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch currency rate revisions for %s-%s: %w", baseCurrency, currency, err)
+	}
+
+	revisions := make([]internal.CurrencyRateRevision, 0)
+
+	defer rows.Close()
+
+	for rows.Next() {
+		revision := internal.CurrencyRateRevision{}
+
+		err = rows.Scan(&revision.Date, &revision.Base, &revision.Currency, &revision.Rate, &revision.Derived, &revision.Provider, &revision.Spread, &revision.RecordedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch currency rate revisions for %s-%s: %w", baseCurrency, currency, err)
+		}
+
+		revisions = append(revisions, revision)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch currency rate revisions for %s-%s: %w", baseCurrency, currency, err)
+	}
+
+	return revisions, nil
+}
+
+func ratesSource(ctx context.Context, args ...any) (string, []any) {
+	asKnownAt, ok := internal.AsKnownAt(ctx)
+	if !ok {
+		return "app.currency_rates", args
+	}
+
+	args = append(args, asKnownAt)
+
+	return fmt.Sprintf(`(
+    SELECT DISTINCT ON (date, base, currency) date, base, currency, rate, derived, provider, spread FROM app.currency_rate_revisions
+    WHERE recorded_at <= $%d
+    ORDER BY date, base, currency, recorded_at DESC
+) AS currency_rates`, len(args)), args
+}
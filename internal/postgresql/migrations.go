@@ -0,0 +1,172 @@
+package postgresql
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"time"
+
+	"github.com/fedorov-dmitry/go-test-api/internal/migration"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+const migrationLockID = 4201839627
+
+type Migrator struct {
+	pgPool     *pgxpool.Pool
+	migrations []migration.Migration
+}
+
+func NewMigrator(pgPool *pgxpool.Pool) (*Migrator, error) {
+	sub, err := fs.Sub(migrationFiles, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embedded migrations: %w", err)
+	}
+
+	migrations, err := migration.Load(sub)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{pgPool: pgPool, migrations: migrations}, nil
+}
+
+func (m *Migrator) Up(ctx context.Context) ([]migration.Migration, error) {
+	applied := make([]migration.Migration, 0)
+
+	err := m.withLock(ctx, func(conn *pgxpool.Conn, versions map[int]time.Time) error {
+		for _, pending := range m.migrations {
+			if _, ok := versions[pending.Version]; ok {
+				continue
+			}
+
+			err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+				_, err := tx.Exec(ctx, pending.Up)
+				if err != nil {
+					return err
+				}
+
+				_, err = tx.Exec(ctx, `INSERT INTO app.schema_migrations (version, name) VALUES ($1, $2)`, pending.Version, pending.Name)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("failed to apply migration %04d_%s: %w", pending.Version, pending.Name, err)
+			}
+
+			applied = append(applied, pending)
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+func (m *Migrator) Down(ctx context.Context, steps int) ([]migration.Migration, error) {
+	reverted := make([]migration.Migration, 0)
+
+	err := m.withLock(ctx, func(conn *pgxpool.Conn, versions map[int]time.Time) error {
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			applied := m.migrations[i]
+			if _, ok := versions[applied.Version]; !ok {
+				continue
+			}
+
+			if applied.Down == "" {
+				return fmt.Errorf("migration %04d_%s cannot be reverted: no down migration", applied.Version, applied.Name)
+			}
+
+			err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+				_, err := tx.Exec(ctx, applied.Down)
+				if err != nil {
+					return err
+				}
+
+				_, err = tx.Exec(ctx, `DELETE FROM app.schema_migrations WHERE version = $1`, applied.Version)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("failed to revert migration %04d_%s: %w", applied.Version, applied.Name, err)
+			}
+
+			reverted = append(reverted, applied)
+		}
+
+		return nil
+	})
+
+	return reverted, err
+}
+
+func (m *Migrator) Status(ctx context.Context) ([]migration.Status, error) {
+	var statuses []migration.Status
+
+	err := m.withLock(ctx, func(_ *pgxpool.Conn, versions map[int]time.Time) error {
+		statuses = migration.Statuses(m.migrations, versions)
+		return nil
+	})
+
+	return statuses, err
+}
+
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *pgxpool.Conn, versions map[int]time.Time) error) error {
+	conn, err := m.pgPool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection for migrations: %w", err)
+	}
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, migrationLockID)
+	if err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer func() {
+		_, _ = conn.Exec(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock($1)`, migrationLockID)
+	}()
+
+	_, err = conn.Exec(ctx, `
+CREATE SCHEMA IF NOT EXISTS app;
+
+CREATE TABLE IF NOT EXISTS app.schema_migrations
+(
+    version    integer     PRIMARY KEY,
+    name       text        NOT NULL,
+    applied_at timestamptz NOT NULL DEFAULT now()
+);`)
+	if err != nil {
+		return fmt.Errorf("failed to create migrations table: %w", err)
+	}
+
+	rows, err := conn.Query(ctx, `SELECT version, applied_at FROM app.schema_migrations`)
+	if err != nil {
+		return fmt.Errorf("failed to fetch applied migrations: %w", err)
+	}
+
+	versions := make(map[int]time.Time)
+
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+
+		err = rows.Scan(&version, &appliedAt)
+		if err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to fetch applied migrations: %w", err)
+		}
+
+		versions[version] = appliedAt
+	}
+	rows.Close()
+
+	err = rows.Err()
+	if err != nil {
+		return fmt.Errorf("failed to fetch applied migrations: %w", err)
+	}
+
+	return fn(conn, versions)
+}
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS app.logs;
+
+DROP TABLE IF EXISTS app.currency_rates;
@@ -14,5 +14,3 @@ CREATE TABLE IF NOT EXISTS app.logs
     timestamp TIMESTAMP    NOT NULL,
     path      varchar(100) NOT NULL
 );
-
-
@@ -0,0 +1,4 @@
+ALTER TABLE app.currency_rates
+    DROP COLUMN IF EXISTS spread,
+    DROP COLUMN IF EXISTS provider,
+    DROP COLUMN IF EXISTS derived;
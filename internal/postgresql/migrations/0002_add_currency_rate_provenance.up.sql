@@ -0,0 +1,7 @@
+ALTER TABLE app.currency_rates
+    ADD COLUMN IF NOT EXISTS derived  boolean      NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS provider varchar(255) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS spread   numeric      NOT NULL DEFAULT 0;
+
+ALTER TABLE app.currency_rates
+    ALTER COLUMN provider TYPE varchar(255);
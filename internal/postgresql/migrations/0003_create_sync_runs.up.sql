@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS app.sync_runs
+(
+    id           bigserial   PRIMARY KEY,
+    trigger      varchar(20) NOT NULL,
+    status       varchar(20) NOT NULL,
+    started_at   timestamptz NOT NULL,
+    finished_at  timestamptz,
+    bases        text[]      NOT NULL DEFAULT '{}',
+    dates        date[]      NOT NULL DEFAULT '{}',
+    rows_written integer     NOT NULL DEFAULT 0,
+    errors       text[]      NOT NULL DEFAULT '{}'
+);
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS app.quarantined_rates
+(
+    id            bigserial    PRIMARY KEY,
+    date          date         NOT NULL,
+    base          varchar(5)   NOT NULL,
+    currency      varchar(5)   NOT NULL,
+    rate          numeric      NOT NULL,
+    derived       boolean      NOT NULL DEFAULT false,
+    provider      varchar(255) NOT NULL DEFAULT '',
+    spread        numeric      NOT NULL DEFAULT 0,
+    previous_date date         NOT NULL,
+    previous_rate numeric      NOT NULL,
+    change        numeric      NOT NULL,
+    status        varchar(20)  NOT NULL,
+    created_at    timestamptz  NOT NULL,
+    resolved_at   timestamptz
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS quarantined_rates_pending_idx
+    ON app.quarantined_rates (date, base, currency)
+    WHERE status = 'pending';
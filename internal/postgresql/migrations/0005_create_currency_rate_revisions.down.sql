@@ -0,0 +1 @@
+DROP TABLE IF EXISTS app.currency_rate_revisions;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS app.currency_rate_revisions
+(
+    date        date         NOT NULL,
+    base        varchar(5)   NOT NULL,
+    currency    varchar(5)   NOT NULL,
+    rate        numeric      NOT NULL,
+    derived     boolean      NOT NULL DEFAULT false,
+    provider    varchar(255) NOT NULL DEFAULT '',
+    spread      numeric      NOT NULL DEFAULT 0,
+    recorded_at timestamptz  NOT NULL,
+    PRIMARY KEY (date, base, currency, recorded_at)
+);
+
+INSERT INTO app.currency_rate_revisions (date, base, currency, rate, derived, provider, spread, recorded_at)
+SELECT date, base, currency, rate, derived, provider, spread, now() FROM app.currency_rates
+ON CONFLICT DO NOTHING;
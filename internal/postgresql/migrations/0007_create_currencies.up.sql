@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS app.currencies
+(
+    code         varchar(5)   PRIMARY KEY,
+    numeric_code varchar(3)   NOT NULL DEFAULT '',
+    name         varchar(255) NOT NULL,
+    minor_units  integer      NOT NULL,
+    active       boolean      NOT NULL DEFAULT true,
+    kind         varchar(20)  NOT NULL
+);
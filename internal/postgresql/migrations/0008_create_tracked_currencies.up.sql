@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS app.tracked_currencies
+(
+    currency varchar(5)  PRIMARY KEY,
+    added_at timestamptz NOT NULL DEFAULT now()
+);
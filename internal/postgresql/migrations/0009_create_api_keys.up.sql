@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS app.api_keys
+(
+    id         bigserial    PRIMARY KEY,
+    name       varchar(255) NOT NULL,
+    prefix     varchar(16)  NOT NULL,
+    key_hash   varchar(64)  NOT NULL UNIQUE,
+    scopes     text[]       NOT NULL DEFAULT '{}',
+    created_at timestamptz  NOT NULL,
+    expires_at timestamptz,
+    revoked_at timestamptz
+);
@@ -0,0 +1,32 @@
+package postgresql
+
+import (
+	"io/fs"
+	"testing"
+
+	"github.com/fedorov-dmitry/go-test-api/internal/migration"
+)
+
+func TestLoadMigrations_Embedded(t *testing.T) {
+	t.Parallel()
+
+	sub, err := fs.Sub(migrationFiles, "migrations")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	migrations, err := migration.Load(sub)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Versions are shared between backends, so a backend skips the versions of migrations it does not need
+	for i, m := range migrations {
+		if i == 0 && m.Version != 1 || i > 0 && m.Version <= migrations[i-1].Version {
+			t.Fatalf("migration %s has version %d, want versions starting at 1 and increasing", m.Name, m.Version)
+		}
+		if m.Down == "" {
+			t.Fatalf("migration %04d_%s has no down migration", m.Version, m.Name)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fedorov-dmitry/go-test-api/internal"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type QuarantineStorage struct {
+	pgPool *pgxpool.Pool
+}
+
+func NewQuarantineStorage(pgPool *pgxpool.Pool) *QuarantineStorage {
+	return &QuarantineStorage{pgPool: pgPool}
+}
+
+func (s *QuarantineStorage) Create(ctx context.Context, rate internal.QuarantinedRate) (int64, error) {
+	sql := `
+INSERT INTO app.quarantined_rates (date, base, currency, rate, derived, provider, spread, previous_date, previous_rate, change, status, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+ON CONFLICT (date, base, currency) WHERE status = 'pending'
+DO UPDATE SET
+   rate = EXCLUDED.rate,
+   derived = EXCLUDED.derived,
+   provider = EXCLUDED.provider,
+   spread = EXCLUDED.spread,
+   previous_date = EXCLUDED.previous_date,
+   previous_rate = EXCLUDED.previous_rate,
+   change = EXCLUDED.change
+RETURNING id`
+
+	var id int64
+
+	err := s.pgPool.QueryRow(ctx, sql,
+		rate.Rate.Date, rate.Rate.Base, rate.Rate.Currency, rate.Rate.Rate, rate.Rate.Derived, rate.Rate.Provider, rate.Rate.Spread,
+		rate.PreviousDate, rate.PreviousRate, rate.Change, rate.Status, rate.CreatedAt,
+	).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create quarantined rate: %w", err)
+	}
+
+	return id, nil
+}
+
+func (s *QuarantineStorage) Get(ctx context.Context, id int64) (internal.QuarantinedRate, error) {
+	sql := `
+SELECT id, date, base, currency, rate, derived, provider, spread, previous_date, previous_rate, change, status, created_at, resolved_at FROM app.quarantined_rates
+WHERE id = $1`
+
+	rate, err := scanQuarantinedRate(s.pgPool.QueryRow(ctx, sql, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = internal.ErrQuarantinedRateNotFound
+	}
+	if err != nil {
+		return internal.QuarantinedRate{}, fmt.Errorf("failed to get quarantined rate %d: %w", id, err)
+	}
+
+	return rate, nil
+}
+
+func (s *QuarantineStorage) List(ctx context.Context, status internal.QuarantineStatus, limit int) ([]internal.QuarantinedRate, error) {
+	sql := `
+SELECT id, date, base, currency, rate, derived, provider, spread, previous_date, previous_rate, change, status, created_at, resolved_at FROM app.quarantined_rates
+WHERE $1 = '' OR status = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2`
+
+	rows, err := s.pgPool.Query(ctx, sql, status, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch quarantined rates: %w", err)
+	}
+
+	rates := make([]internal.QuarantinedRate, 0)
+
+	defer rows.Close()
+
+	for rows.Next() {
+		rate, err := scanQuarantinedRate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch quarantined rates: %w", err)
+		}
+
+		rates = append(rates, rate)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch quarantined rates: %w", err)
+	}
+
+	return rates, nil
+}
+
+func (s *QuarantineStorage) Update(ctx context.Context, rate internal.QuarantinedRate) error {
+	sql := `
+UPDATE app.quarantined_rates
+SET status = $2,
+    resolved_at = $3
+WHERE id = $1`
+
+	_, err := s.pgPool.Exec(ctx, sql, rate.ID, rate.Status, rate.ResolvedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update quarantined rate %d: %w", rate.ID, err)
+	}
+
+	return nil
+}
+
+func (s *QuarantineStorage) GetRejected(ctx context.Context, rate internal.CurrencyRate) (internal.QuarantinedRate, error) {
+	sql := `
+SELECT id, date, base, currency, rate, derived, provider, spread, previous_date, previous_rate, change, status, created_at, resolved_at FROM app.quarantined_rates
+WHERE date = $1
+  AND base = $2
+  AND currency = $3
+  AND rate = $4
+  AND status = 'rejected'
+ORDER BY resolved_at DESC, id DESC
+LIMIT 1`
+
+	rejected, err := scanQuarantinedRate(s.pgPool.QueryRow(ctx, sql, rate.Date, rate.Base, rate.Currency, rate.Rate))
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = internal.ErrQuarantinedRateNotFound
+	}
+	if err != nil {
+		return internal.QuarantinedRate{}, fmt.Errorf("failed to get rejected rate for %s-%s: %w", rate.Base, rate.Currency, err)
+	}
+
+	return rejected, nil
+}
+
+func (s *QuarantineStorage) Approve(ctx context.Context, rate internal.QuarantinedRate) error {
+	sql := `
+UPDATE app.quarantined_rates
+SET status = $2,
+    resolved_at = $3
+WHERE id = $1
+  AND status = 'pending'`
+
+	err := pgx.BeginFunc(ctx, s.pgPool, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, sql, rate.ID, rate.Status, rate.ResolvedAt)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return internal.ErrQuarantinedRateResolved
+		}
+
+		_, err = tx.Exec(ctx, setCurrencyRateSQL, rate.Rate.Date, rate.Rate.Base, rate.Rate.Currency, rate.Rate.Rate, rate.Rate.Derived, rate.Rate.Provider, rate.Rate.Spread)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to approve quarantined rate %d: %w", rate.ID, err)
+	}
+
+	return nil
+}
+
+func scanQuarantinedRate(row pgx.Row) (internal.QuarantinedRate, error) {
+	rate := internal.QuarantinedRate{}
+
+	err := row.Scan(&rate.ID, &rate.Rate.Date, &rate.Rate.Base, &rate.Rate.Currency, &rate.Rate.Rate, &rate.Rate.Derived, &rate.Rate.Provider, &rate.Rate.Spread,
+		&rate.PreviousDate, &rate.PreviousRate, &rate.Change, &rate.Status, &rate.CreatedAt, &rate.ResolvedAt)
+	if err != nil {
+		return internal.QuarantinedRate{}, err
+	}
+
+	return rate, nil
+}
@@ -0,0 +1,28 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fedorov-dmitry/go-test-api/internal/middleware"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type RequestLogStorage struct {
+	pgPool *pgxpool.Pool
+}
+
+func NewRequestLogStorage(pgPool *pgxpool.Pool) *RequestLogStorage {
+	return &RequestLogStorage{pgPool: pgPool}
+}
+
+func (s *RequestLogStorage) Add(ctx context.Context, entry middleware.RequestLog) error {
+	sql := "INSERT INTO app.logs (timestamp, path) VALUES ($1, $2)"
+
+	_, err := s.pgPool.Exec(ctx, sql, entry.Timestamp, entry.Path)
+	if err != nil {
+		return fmt.Errorf("failed to insert log entry: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fedorov-dmitry/go-test-api/internal"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type SyncRunStorage struct {
+	pgPool *pgxpool.Pool
+}
+
+func NewSyncRunStorage(pgPool *pgxpool.Pool) *SyncRunStorage {
+	return &SyncRunStorage{pgPool: pgPool}
+}
+
+func (s *SyncRunStorage) Create(ctx context.Context, run internal.SyncRun) (int64, error) {
+	sql := `
+INSERT INTO app.sync_runs (trigger, status, started_at, finished_at, bases, dates, rows_written, errors)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id`
+
+	var id int64
+
+	err := s.pgPool.QueryRow(ctx, sql, run.Trigger, run.Status, run.StartedAt, run.FinishedAt, currencyStrings(run.Bases), run.Dates, run.RowsWritten, run.Errors).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create sync run: %w", err)
+	}
+
+	return id, nil
+}
+
+func (s *SyncRunStorage) Update(ctx context.Context, run internal.SyncRun) error {
+	sql := `
+UPDATE app.sync_runs
+SET status = $2,
+    finished_at = $3,
+    rows_written = $4,
+    errors = $5
+WHERE id = $1`
+
+	_, err := s.pgPool.Exec(ctx, sql, run.ID, run.Status, run.FinishedAt, run.RowsWritten, run.Errors)
+	if err != nil {
+		return fmt.Errorf("failed to update sync run %d: %w", run.ID, err)
+	}
+
+	return nil
+}
+
+func (s *SyncRunStorage) List(ctx context.Context, limit int) ([]internal.SyncRun, error) {
+	sql := `
+SELECT id, trigger, status, started_at, finished_at, bases, dates, rows_written, errors FROM app.sync_runs
+ORDER BY started_at DESC, id DESC
+LIMIT $1`
+
+	rows, err := s.pgPool.Query(ctx, sql, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch sync runs: %w", err)
+	}
+
+	runs := make([]internal.SyncRun, 0)
+
+	defer rows.Close()
+
+	for rows.Next() {
+		run, err := scanSyncRun(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch sync runs: %w", err)
+		}
+
+		runs = append(runs, run)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch sync runs: %w", err)
+	}
+
+	return runs, nil
+}
+
+func (s *SyncRunStorage) GetLastSucceeded(ctx context.Context) (internal.SyncRun, error) {
+	sql := `
+SELECT id, trigger, status, started_at, finished_at, bases, dates, rows_written, errors FROM app.sync_runs
+WHERE status = $1
+ORDER BY finished_at DESC, id DESC
+LIMIT 1`
+
+	run, err := scanSyncRun(s.pgPool.QueryRow(ctx, sql, internal.SyncRunStatusSucceeded))
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = internal.ErrSyncRunNotFound
+	}
+	if err != nil {
+		return internal.SyncRun{}, fmt.Errorf("failed to get last successful sync run: %w", err)
+	}
+
+	return run, nil
+}
+
+func scanSyncRun(row pgx.Row) (internal.SyncRun, error) {
+	run := internal.SyncRun{}
+
+	var bases []string
+	var dates []time.Time
+
+	err := row.Scan(&run.ID, &run.Trigger, &run.Status, &run.StartedAt, &run.FinishedAt, &bases, &dates, &run.RowsWritten, &run.Errors)
+	if err != nil {
+		return internal.SyncRun{}, err
+	}
+
+	run.Bases = make([]internal.Currency, len(bases))
+	for i, base := range bases {
+		run.Bases[i] = internal.Currency(base)
+	}
+	run.Dates = dates
+
+	return run, nil
+}
+
+func currencyStrings(currencies []internal.Currency) []string {
+	result := make([]string, len(currencies))
+	for i, currency := range currencies {
+		result[i] = string(currency)
+	}
+
+	return result
+}
@@ -0,0 +1,85 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fedorov-dmitry/go-test-api/internal"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TrackedCurrencyStorage struct {
+	pgPool *pgxpool.Pool
+}
+
+func NewTrackedCurrencyStorage(pgPool *pgxpool.Pool) *TrackedCurrencyStorage {
+	return &TrackedCurrencyStorage{pgPool: pgPool}
+}
+
+func (s *TrackedCurrencyStorage) List(ctx context.Context) ([]internal.TrackedCurrency, error) {
+	sql := `
+SELECT currency, added_at FROM app.tracked_currencies
+ORDER BY added_at, currency`
+
+	rows, err := s.pgPool.Query(ctx, sql)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch tracked currencies: %w", err)
+	}
+
+	currencies := make([]internal.TrackedCurrency, 0)
+
+	defer rows.Close()
+
+	for rows.Next() {
+		currency := internal.TrackedCurrency{}
+
+		err = rows.Scan(&currency.Currency, &currency.AddedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch tracked currencies: %w", err)
+		}
+
+		currencies = append(currencies, currency)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch tracked currencies: %w", err)
+	}
+
+	return currencies, nil
+}
+
+func (s *TrackedCurrencyStorage) Add(ctx context.Context, currency internal.TrackedCurrency) error {
+	sql := `
+INSERT INTO app.tracked_currencies (currency, added_at)
+VALUES ($1, $2)
+ON CONFLICT (currency) DO NOTHING`
+
+	tag, err := s.pgPool.Exec(ctx, sql, currency.Currency, currency.AddedAt)
+	if err != nil {
+		return fmt.Errorf("failed to add tracked currency %s: %w", currency.Currency, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("failed to add tracked currency %s: %w", currency.Currency, internal.ErrCurrencyAlreadyTracked)
+	}
+
+	return nil
+}
+
+func (s *TrackedCurrencyStorage) Remove(ctx context.Context, currency internal.Currency) error {
+	sql := `
+DELETE FROM app.tracked_currencies
+WHERE currency = $1`
+
+	tag, err := s.pgPool.Exec(ctx, sql, currency)
+	if err != nil {
+		return fmt.Errorf("failed to remove tracked currency %s: %w", currency, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("failed to remove tracked currency %s: %w", currency, internal.ErrCurrencyNotTracked)
+	}
+
+	return nil
+}
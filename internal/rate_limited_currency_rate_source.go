@@ -0,0 +1,73 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type HostRateLimiter struct {
+	interval time.Duration
+
+	mu   sync.Mutex
+	next map[string]time.Time
+}
+
+func NewHostRateLimiter(requestsPerSecond float64) *HostRateLimiter {
+	interval := time.Duration(0)
+	if requestsPerSecond > 0 {
+		interval = time.Duration(float64(time.Second) / requestsPerSecond)
+	}
+
+	return &HostRateLimiter{interval: interval, next: make(map[string]time.Time)}
+}
+
+func (l *HostRateLimiter) Wait(ctx context.Context, host string) error {
+	if l.interval == 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	next := l.next[host]
+	if next.Before(now) {
+		next = now
+	}
+	delay := next.Sub(now)
+	l.next[host] = next.Add(l.interval)
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("rate limited request to %s cancelled: %w", host, ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
+
+type RateLimitedCurrencyRateSource struct {
+	source  CurrencyRateSource
+	limiter *HostRateLimiter
+	host    string
+}
+
+func NewRateLimitedCurrencyRateSource(source CurrencyRateSource, limiter *HostRateLimiter, host string) *RateLimitedCurrencyRateSource {
+	return &RateLimitedCurrencyRateSource{source: source, limiter: limiter, host: host}
+}
+
+func (s *RateLimitedCurrencyRateSource) Get(ctx context.Context, baseCurrency Currency, currencies []Currency, date time.Time) ([]CurrencyRate, error) {
+	err := s.limiter.Wait(ctx, s.host)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.source.Get(ctx, baseCurrency, currencies, date)
+}
@@ -0,0 +1,84 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fedorov-dmitry/go-test-api/internal"
+	"github.com/fedorov-dmitry/go-test-api/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRateLimitedCurrencyRateSource_SpacesRequestsPerHost(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSource := mocks.NewMockCurrencyRateSource(ctrl)
+	limiter := internal.NewHostRateLimiter(50)
+
+	// two sources on the same host share one budget
+	first := internal.NewRateLimitedCurrencyRateSource(mockSource, limiter, "cdn.jsdelivr.net")
+	second := internal.NewRateLimitedCurrencyRateSource(mockSource, limiter, "cdn.jsdelivr.net")
+	other := internal.NewRateLimitedCurrencyRateSource(mockSource, limiter, "www.ecb.europa.eu")
+
+	mockSource.
+		EXPECT().
+		Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, nil).
+		Times(4)
+
+	ctx := context.Background()
+	start := time.Now()
+	for _, source := range []*internal.RateLimitedCurrencyRateSource{first, second, first} {
+		if _, err := source.Get(ctx, internal.NewCurrency("usd"), nil, start); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Fatalf("3 requests at 50 rps to one host took %s, want at least 40ms", elapsed)
+	}
+
+	otherStart := time.Now()
+	if _, err := other.Get(ctx, internal.NewCurrency("usd"), nil, start); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(otherStart); elapsed > 10*time.Millisecond {
+		t.Fatalf("a request to another host waited %s", elapsed)
+	}
+}
+
+func TestRateLimitedCurrencyRateSource_StopsWaitingOnCancel(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSource := mocks.NewMockCurrencyRateSource(ctrl)
+	source := internal.NewRateLimitedCurrencyRateSource(mockSource, internal.NewHostRateLimiter(0.1), "cdn.jsdelivr.net")
+
+	mockSource.
+		EXPECT().
+		Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, nil)
+
+	if _, err := source.Get(context.Background(), internal.NewCurrency("usd"), nil, time.Now()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := source.Get(ctx, internal.NewCurrency("usd"), nil, time.Now())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("cancelled request waited %s", elapsed)
+	}
+}
@@ -0,0 +1,146 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrQuarantinedRateNotFound = errors.New("quarantined rate not found")
+	ErrQuarantinedRateResolved = errors.New("quarantined rate already resolved")
+)
+
+type QuarantineStatus string
+
+const (
+	QuarantineStatusPending  QuarantineStatus = "pending"
+	QuarantineStatusApproved QuarantineStatus = "approved"
+	QuarantineStatusRejected QuarantineStatus = "rejected"
+)
+
+type QuarantinedRate struct {
+	ID           int64
+	Rate         CurrencyRate
+	PreviousDate time.Time
+	PreviousRate decimal.Decimal
+	Change       float64
+	Status       QuarantineStatus
+	CreatedAt    time.Time
+	ResolvedAt   *time.Time
+}
+
+type QuarantineStorage interface {
+	Create(ctx context.Context, rate QuarantinedRate) (int64, error)
+	Get(ctx context.Context, id int64) (QuarantinedRate, error)
+	List(ctx context.Context, status QuarantineStatus, limit int) ([]QuarantinedRate, error)
+	Update(ctx context.Context, rate QuarantinedRate) error
+	GetRejected(ctx context.Context, rate CurrencyRate) (QuarantinedRate, error)
+	Approve(ctx context.Context, rate QuarantinedRate) error
+}
+
+type QuarantineRepository struct {
+	storage QuarantineStorage
+	rates   *CurrencyRepository
+}
+
+func NewQuarantineRepository(storage QuarantineStorage, rates *CurrencyRepository) *QuarantineRepository {
+	return &QuarantineRepository{storage: storage, rates: rates}
+}
+
+func (repo *QuarantineRepository) Add(ctx context.Context, rate CurrencyRate, previous CurrencyRate, change float64) (QuarantinedRate, error) {
+	rejected, err := repo.storage.GetRejected(ctx, rate)
+	if err == nil {
+		return rejected, nil
+	}
+	if !errors.Is(err, ErrQuarantinedRateNotFound) {
+		return QuarantinedRate{}, fmt.Errorf("failed to quarantine currency rate %s-%s for %s: %w", rate.Base, rate.Currency, rate.Date.Format("2006-01-02"), err)
+	}
+
+	quarantined := QuarantinedRate{
+		Rate:         rate,
+		PreviousDate: previous.Date,
+		PreviousRate: previous.Rate,
+		Change:       change,
+		Status:       QuarantineStatusPending,
+		CreatedAt:    time.Now().UTC(),
+	}
+
+	id, err := repo.storage.Create(ctx, quarantined)
+	if err != nil {
+		return QuarantinedRate{}, fmt.Errorf("failed to quarantine currency rate %s-%s for %s: %w", rate.Base, rate.Currency, rate.Date.Format("2006-01-02"), err)
+	}
+
+	quarantined.ID = id
+
+	return quarantined, nil
+}
+
+func (repo *QuarantineRepository) List(ctx context.Context, status QuarantineStatus, limit int) ([]QuarantinedRate, error) {
+	rates, err := repo.storage.List(ctx, status, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list quarantined currency rates: %w", err)
+	}
+
+	return rates, nil
+}
+
+func (repo *QuarantineRepository) Approve(ctx context.Context, id int64) (QuarantinedRate, error) {
+	quarantined, err := repo.pending(ctx, id)
+	if err != nil {
+		return QuarantinedRate{}, err
+	}
+
+	resolvedAt := time.Now().UTC()
+
+	quarantined.Status = QuarantineStatusApproved
+	quarantined.ResolvedAt = &resolvedAt
+
+	err = repo.storage.Approve(ctx, quarantined)
+	if err != nil {
+		return QuarantinedRate{}, fmt.Errorf("failed to approve quarantined currency rate %d: %w", id, err)
+	}
+
+	repo.rates.Invalidate(quarantined.Rate)
+
+	return quarantined, nil
+}
+
+func (repo *QuarantineRepository) Reject(ctx context.Context, id int64) (QuarantinedRate, error) {
+	quarantined, err := repo.pending(ctx, id)
+	if err != nil {
+		return QuarantinedRate{}, err
+	}
+
+	return repo.resolve(ctx, quarantined, QuarantineStatusRejected)
+}
+
+func (repo *QuarantineRepository) pending(ctx context.Context, id int64) (QuarantinedRate, error) {
+	quarantined, err := repo.storage.Get(ctx, id)
+	if err != nil {
+		return QuarantinedRate{}, fmt.Errorf("failed to get quarantined currency rate %d: %w", id, err)
+	}
+
+	if quarantined.Status != QuarantineStatusPending {
+		return QuarantinedRate{}, fmt.Errorf("quarantined currency rate %d is %s: %w", id, quarantined.Status, ErrQuarantinedRateResolved)
+	}
+
+	return quarantined, nil
+}
+
+func (repo *QuarantineRepository) resolve(ctx context.Context, quarantined QuarantinedRate, status QuarantineStatus) (QuarantinedRate, error) {
+	resolvedAt := time.Now().UTC()
+
+	quarantined.Status = status
+	quarantined.ResolvedAt = &resolvedAt
+
+	err := repo.storage.Update(ctx, quarantined)
+	if err != nil {
+		return QuarantinedRate{}, fmt.Errorf("failed to mark quarantined currency rate %d as %s: %w", quarantined.ID, status, err)
+	}
+
+	return quarantined, nil
+}
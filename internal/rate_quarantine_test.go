@@ -0,0 +1,119 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fedorov-dmitry/go-test-api/internal"
+	"github.com/fedorov-dmitry/go-test-api/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestQuarantineRepository_Approve_SavesRate(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockStorage := mocks.NewMockCurrencyStorage(ctrl)
+	mockQuarantine := mocks.NewMockQuarantineStorage(ctrl)
+	rates := internal.NewCurrencyRepository(internal.NewCachedCurrencyStorage(mockStorage, 10, time.Hour, time.Hour))
+	repo := internal.NewQuarantineRepository(mockQuarantine, rates)
+
+	rate := internal.CurrencyRate{Date: time.Date(2025, 1, 14, 0, 0, 0, 0, time.UTC), Base: "usd", Currency: "jpy", Rate: dec("220")}
+
+	// the cached lookup is loaded again once the approved rate is written
+	mockStorage.
+		EXPECT().
+		Get(ctx, rate.Base, rate.Currency, rate.Date).
+		Return(internal.CurrencyRate{}, internal.ErrRateNotFound)
+	mockStorage.
+		EXPECT().
+		Get(ctx, rate.Currency, rate.Base, rate.Date).
+		Return(internal.CurrencyRate{}, internal.ErrRateNotFound)
+	mockStorage.
+		EXPECT().
+		Get(ctx, rate.Base, rate.Currency, rate.Date).
+		Return(rate, nil)
+
+	if _, err := rates.Get(ctx, rate.Base, rate.Currency, rate.Date); !errors.Is(err, internal.ErrRateNotFound) {
+		t.Fatalf("expected ErrRateNotFound, got %v", err)
+	}
+
+	mockQuarantine.
+		EXPECT().
+		Get(ctx, int64(7)).
+		Return(internal.QuarantinedRate{ID: 7, Rate: rate, Status: internal.QuarantineStatusPending}, nil)
+	mockQuarantine.
+		EXPECT().
+		Approve(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, q internal.QuarantinedRate) error {
+			if q.ID != 7 || !q.Rate.Equal(rate) || q.Status != internal.QuarantineStatusApproved || q.ResolvedAt == nil {
+				t.Fatalf("unexpected update: %+v", q)
+			}
+			return nil
+		})
+
+	approved, err := repo.Approve(ctx, 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if approved.Status != internal.QuarantineStatusApproved {
+		t.Fatalf("Status=%s, want approved", approved.Status)
+	}
+
+	if saved, err := rates.Get(ctx, rate.Base, rate.Currency, rate.Date); err != nil || !saved.Rate.Equal(rate.Rate) {
+		t.Fatalf("got %+v, %v, want the approved rate", saved, err)
+	}
+}
+
+func TestQuarantineRepository_Reject_AlreadyResolved(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockQuarantine := mocks.NewMockQuarantineStorage(ctrl)
+	repo := internal.NewQuarantineRepository(mockQuarantine, internal.NewCurrencyRepository(mocks.NewMockCurrencyStorage(ctrl)))
+
+	mockQuarantine.
+		EXPECT().
+		Get(ctx, int64(7)).
+		Return(internal.QuarantinedRate{ID: 7, Status: internal.QuarantineStatusApproved}, nil)
+
+	_, err := repo.Reject(ctx, 7)
+	if !errors.Is(err, internal.ErrQuarantinedRateResolved) {
+		t.Fatalf("expected ErrQuarantinedRateResolved, got %v", err)
+	}
+}
+
+func TestQuarantineRepository_Add_KeepsRejectedRate(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockQuarantine := mocks.NewMockQuarantineStorage(ctrl)
+	repo := internal.NewQuarantineRepository(mockQuarantine, internal.NewCurrencyRepository(mocks.NewMockCurrencyStorage(ctrl)))
+
+	rate := internal.CurrencyRate{Date: time.Date(2025, 1, 14, 0, 0, 0, 0, time.UTC), Base: "usd", Currency: "jpy", Rate: dec("220")}
+	previous := internal.CurrencyRate{Date: rate.Date.AddDate(0, 0, -1), Base: "usd", Currency: "jpy", Rate: dec("145")}
+
+	mockQuarantine.
+		EXPECT().
+		GetRejected(ctx, rate).
+		Return(internal.QuarantinedRate{ID: 7, Rate: rate, Status: internal.QuarantineStatusRejected}, nil)
+
+	quarantined, err := repo.Add(ctx, rate, previous, 0.5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if quarantined.ID != 7 || quarantined.Status != internal.QuarantineStatusRejected {
+		t.Fatalf("expected rejected entry #7, got %+v", quarantined)
+	}
+}
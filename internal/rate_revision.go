@@ -0,0 +1,22 @@
+package internal
+
+import (
+	"context"
+	"time"
+)
+
+type CurrencyRateRevision struct {
+	CurrencyRate
+	RecordedAt time.Time
+}
+
+type asKnownAtKey struct{}
+
+func WithAsKnownAt(ctx context.Context, asKnownAt time.Time) context.Context {
+	return context.WithValue(ctx, asKnownAtKey{}, asKnownAt)
+}
+
+func AsKnownAt(ctx context.Context) (time.Time, bool) {
+	asKnownAt, ok := ctx.Value(asKnownAtKey{}).(time.Time)
+	return asKnownAt, ok
+}
@@ -3,14 +3,36 @@ package internal_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
 	"testing"
 	"time"
 
 	"github.com/fedorov-dmitry/go-test-api/internal"
 	"github.com/fedorov-dmitry/go-test-api/internal/mocks"
+	"github.com/shopspring/decimal"
 	"go.uber.org/mock/gomock"
 )
 
+func dec(value string) decimal.Decimal {
+	return decimal.RequireFromString(value)
+}
+
+type ratesMatcher []internal.CurrencyRate
+
+func equalRates(rates ...internal.CurrencyRate) gomock.Matcher {
+	return ratesMatcher(rates)
+}
+
+func (m ratesMatcher) Matches(x any) bool {
+	rates, ok := x.([]internal.CurrencyRate)
+	return ok && slices.EqualFunc(rates, m, internal.CurrencyRate.Equal)
+}
+
+func (m ratesMatcher) String() string {
+	return fmt.Sprintf("is equal to %v", []internal.CurrencyRate(m))
+}
+
 func TestCurrencyRepository_Get_Success(t *testing.T) {
 	t.Parallel()
 
@@ -29,7 +51,7 @@ func TestCurrencyRepository_Get_Success(t *testing.T) {
 		Date:     date,
 		Base:     base,
 		Currency: cur,
-		Rate:     0.92,
+		Rate:     dec("0.92"),
 	}
 
 	mockStorage.
@@ -84,8 +106,8 @@ func TestCurrencyRepository_GetMany_Success(t *testing.T) {
 	base := internal.NewCurrency("usd")
 	date := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)
 	expected := []internal.CurrencyRate{
-		{Date: date, Base: base, Currency: internal.NewCurrency("eur"), Rate: 0.92},
-		{Date: date, Base: base, Currency: internal.NewCurrency("jpy"), Rate: 145.1},
+		{Date: date, Base: base, Currency: internal.NewCurrency("eur"), Rate: dec("0.92")},
+		{Date: date, Base: base, Currency: internal.NewCurrency("jpy"), Rate: dec("145.1")},
 	}
 
 	mockStorage.
@@ -101,7 +123,7 @@ func TestCurrencyRepository_GetMany_Success(t *testing.T) {
 		t.Fatalf("got len %d, want %d", len(got), len(expected))
 	}
 	for i := range expected {
-		if got[i] != expected[i] {
+		if !got[i].Equal(expected[i]) {
 			t.Fatalf("got[%d]=%+v, want %+v", i, got[i], expected[i])
 		}
 	}
@@ -120,13 +142,13 @@ func TestCurrencyRepository_Create_Success(t *testing.T) {
 	date := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
 	base := internal.NewCurrency("usd")
 	cur := internal.NewCurrency("eur")
-	rate := 0.93
+	rate := dec("0.93")
 
 	mockStorage.
 		EXPECT().
 		Set(ctx, gomock.AssignableToTypeOf(internal.CurrencyRate{})).
 		DoAndReturn(func(_ context.Context, r internal.CurrencyRate) error {
-			if r.Date != date || r.Base != base || r.Currency != cur || r.Rate != rate {
+			if r.Date != date || r.Base != base || r.Currency != cur || !r.Rate.Equal(rate) {
 				t.Fatalf("unexpected rate %+v", r)
 			}
 			return nil
@@ -160,8 +182,293 @@ func TestCurrencyRepository_Create_Error(t *testing.T) {
 		Set(ctx, gomock.Any()).
 		Return(errors.New("write failed"))
 
-	_, err := repo.Create(ctx, date, base, cur, 0.9)
+	_, err := repo.Create(ctx, date, base, cur, dec("0.9"))
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
 }
+
+func TestCurrencyRepository_SaveMany_Empty(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockCurrencyStorage(ctrl)
+	repo := internal.NewCurrencyRepository(mockStorage)
+
+	err := repo.SaveMany(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCurrencyRepository_SaveMany_Error(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockCurrencyStorage(ctrl)
+	repo := internal.NewCurrencyRepository(mockStorage)
+
+	ctx := context.Background()
+	rates := []internal.CurrencyRate{
+		{Date: time.Now(), Base: internal.NewCurrency("usd"), Currency: internal.NewCurrency("eur"), Rate: dec("0.9")},
+	}
+
+	mockStorage.
+		EXPECT().
+		SetMany(ctx, rates).
+		Return(errors.New("write failed"))
+
+	err := repo.SaveMany(ctx, rates)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestCurrencyRepository_GetRange_Success(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockCurrencyStorage(ctrl)
+	repo := internal.NewCurrencyRepository(mockStorage)
+
+	ctx := context.Background()
+	base := internal.NewCurrency("usd")
+	cur := internal.NewCurrency("eur")
+	from := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 14, 0, 0, 0, 0, time.UTC)
+	expected := []internal.CurrencyRate{
+		{Date: from, Base: base, Currency: cur, Rate: dec("0.92")},
+		{Date: to, Base: base, Currency: cur, Rate: dec("0.93")},
+	}
+
+	mockStorage.
+		EXPECT().
+		GetRange(ctx, base, cur, from, to).
+		Return(expected, nil)
+
+	got, err := repo.GetRange(ctx, base, cur, from, to)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("got len %d, want %d", len(got), len(expected))
+	}
+	for i := range expected {
+		if !got[i].Equal(expected[i]) {
+			t.Fatalf("got[%d]=%+v, want %+v", i, got[i], expected[i])
+		}
+	}
+}
+
+func TestCurrencyRepository_GetRange_InvalidRange(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockCurrencyStorage(ctrl)
+	repo := internal.NewCurrencyRepository(mockStorage)
+
+	from := time.Date(2025, 1, 14, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)
+
+	_, err := repo.GetRange(context.Background(), internal.NewCurrency("usd"), internal.NewCurrency("eur"), from, to)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestCurrencyRepository_Get_InvertsOppositeDirection(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockCurrencyStorage(ctrl)
+	repo := internal.NewCurrencyRepository(mockStorage)
+
+	ctx := context.Background()
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+	date := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	mockStorage.
+		EXPECT().
+		Get(ctx, usd, eur, date).
+		Return(internal.CurrencyRate{}, internal.ErrRateNotFound)
+	mockStorage.
+		EXPECT().
+		Get(ctx, eur, usd, date).
+		Return(internal.CurrencyRate{Date: date, Base: eur, Currency: usd, Rate: dec("1.25")}, nil)
+
+	got, err := repo.Get(ctx, usd, eur, date)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Base != usd || got.Currency != eur || !got.Rate.Equal(dec("0.8")) || !got.Derived || !got.Date.Equal(date) {
+		t.Fatalf("got %+v", got)
+	}
+}
+
+func TestCurrencyRepository_Get_TriangulatesThroughPivot(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockCurrencyStorage(ctrl)
+	eur := internal.NewCurrency("eur")
+	repo := internal.NewCurrencyRepository(mockStorage, eur)
+
+	ctx := context.Background()
+	jpy := internal.NewCurrency("jpy")
+	rub := internal.NewCurrency("rub")
+	date := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	mockStorage.EXPECT().Get(ctx, jpy, rub, date).Return(internal.CurrencyRate{}, internal.ErrRateNotFound)
+	mockStorage.EXPECT().Get(ctx, rub, jpy, date).Return(internal.CurrencyRate{}, internal.ErrRateNotFound)
+	mockStorage.EXPECT().Get(ctx, jpy, eur, date).Return(internal.CurrencyRate{}, internal.ErrRateNotFound)
+	mockStorage.
+		EXPECT().
+		Get(ctx, eur, jpy, date).
+		Return(internal.CurrencyRate{Date: date, Base: eur, Currency: jpy, Rate: dec("160")}, nil)
+	mockStorage.
+		EXPECT().
+		Get(ctx, eur, rub, date).
+		Return(internal.CurrencyRate{Date: date, Base: eur, Currency: rub, Rate: dec("100")}, nil)
+
+	got, err := repo.Get(ctx, jpy, rub, date)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Base != jpy || got.Currency != rub || !got.Rate.Equal(dec("0.625")) || !got.Derived {
+		t.Fatalf("got %+v", got)
+	}
+}
+
+func TestCurrencyRepository_Get_NotFound(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockCurrencyStorage(ctrl)
+	repo := internal.NewCurrencyRepository(mockStorage, internal.NewCurrency("eur"))
+
+	mockStorage.
+		EXPECT().
+		Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(internal.CurrencyRate{}, internal.ErrRateNotFound).
+		AnyTimes()
+
+	_, err := repo.Get(context.Background(), internal.NewCurrency("usd"), internal.NewCurrency("jpy"), time.Now())
+	if !errors.Is(err, internal.ErrRateNotFound) {
+		t.Fatalf("expected ErrRateNotFound, got %v", err)
+	}
+}
+
+func TestCurrencyRepository_GetLatest_FallsBackToEarlierDate(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockCurrencyStorage(ctrl)
+	repo := internal.NewCurrencyRepository(mockStorage)
+
+	ctx := context.Background()
+	base := internal.NewCurrency("usd")
+	cur := internal.NewCurrency("eur")
+	requested := time.Date(2025, 1, 13, 0, 30, 0, 0, time.UTC)
+	stored := time.Date(2025, 1, 11, 0, 0, 0, 0, time.UTC)
+
+	mockStorage.
+		EXPECT().
+		GetLatest(ctx, base, cur, requested).
+		Return(internal.CurrencyRate{Date: stored, Base: base, Currency: cur, Rate: dec("0.92")}, nil)
+
+	got, err := repo.GetLatest(ctx, base, cur, requested, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !got.Date.Equal(stored) || !got.Rate.Equal(dec("0.92")) {
+		t.Fatalf("got %+v", got)
+	}
+}
+
+func TestCurrencyRepository_GetLatest_TooStale(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockCurrencyStorage(ctrl)
+	repo := internal.NewCurrencyRepository(mockStorage)
+
+	ctx := context.Background()
+	base := internal.NewCurrency("usd")
+	cur := internal.NewCurrency("eur")
+	requested := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)
+
+	mockStorage.
+		EXPECT().
+		GetLatest(ctx, base, cur, requested).
+		Return(internal.CurrencyRate{Date: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC), Base: base, Currency: cur, Rate: dec("0.92")}, nil)
+	mockStorage.
+		EXPECT().
+		GetLatest(ctx, cur, base, requested).
+		Return(internal.CurrencyRate{}, internal.ErrRateNotFound)
+
+	_, err := repo.GetLatest(ctx, base, cur, requested, 2)
+	if !errors.Is(err, internal.ErrRateNotFound) {
+		t.Fatalf("expected ErrRateNotFound, got %v", err)
+	}
+}
+
+func TestCurrencyRepository_GetLatest_StaleDirectFallsThroughToFresherRates(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockCurrencyStorage(ctrl)
+	repo := internal.NewCurrencyRepository(mockStorage, internal.NewCurrency("eur"))
+
+	ctx := context.Background()
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+	jpy := internal.NewCurrency("jpy")
+	requested := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)
+	stale := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	mockStorage.
+		EXPECT().
+		GetLatest(ctx, usd, jpy, requested).
+		Return(internal.CurrencyRate{Date: stale, Base: usd, Currency: jpy, Rate: dec("110")}, nil)
+	mockStorage.
+		EXPECT().
+		GetLatest(ctx, jpy, usd, requested).
+		Return(internal.CurrencyRate{Date: stale, Base: jpy, Currency: usd, Rate: dec("0.009")}, nil)
+	mockStorage.
+		EXPECT().
+		GetLatest(ctx, usd, eur, requested).
+		Return(internal.CurrencyRate{Date: requested, Base: usd, Currency: eur, Rate: dec("0.9")}, nil)
+	mockStorage.
+		EXPECT().
+		GetLatest(ctx, eur, jpy, requested).
+		Return(internal.CurrencyRate{Date: requested.AddDate(0, 0, -1), Base: eur, Currency: jpy, Rate: dec("160")}, nil)
+
+	got, err := repo.GetLatest(ctx, usd, jpy, requested, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !got.Derived || !got.Rate.Equal(dec("144")) || !got.Date.Equal(requested.AddDate(0, 0, -1)) {
+		t.Fatalf("expected the fresh cross rate via eur, got %+v", got)
+	}
+}
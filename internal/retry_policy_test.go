@@ -0,0 +1,109 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/fedorov-dmitry/go-test-api/internal"
+)
+
+func TestRetryPolicy_Do_StopsAfterMaxAttempts(t *testing.T) {
+	t.Parallel()
+
+	policy := internal.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, Jitter: 0.5}
+
+	calls := 0
+	err := policy.Do(context.Background(), func() error {
+		calls++
+		return errors.New("boom")
+	})
+
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if calls != 3 {
+		t.Fatalf("calls=%d, want 3", calls)
+	}
+}
+
+func TestRetryPolicy_Do_SkipsNonRetryableErrors(t *testing.T) {
+	t.Parallel()
+
+	permanent := errors.New("permanent")
+	policy := internal.RetryPolicy{
+		MaxAttempts: 3,
+		Retryable: func(err error) bool {
+			return !errors.Is(err, permanent)
+		},
+	}
+
+	calls := 0
+	err := policy.Do(context.Background(), func() error {
+		calls++
+		return permanent
+	})
+
+	if !errors.Is(err, permanent) {
+		t.Fatalf("expected permanent error, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("calls=%d, want 1", calls)
+	}
+}
+
+func TestRetryPolicy_Do_StopsOnContextCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	policy := internal.RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Hour}
+
+	calls := 0
+	err := policy.Do(ctx, func() error {
+		calls++
+		cancel()
+		return errors.New("boom")
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("calls=%d, want 1", calls)
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	t.Parallel()
+
+	status := func(code int) error {
+		return fmt.Errorf("bad response from rates API. %w", &internal.HttpStatusError{StatusCode: code, Status: http.StatusText(code)})
+	}
+
+	tests := map[string]struct {
+		err  error
+		want bool
+	}{
+		"network error":   {err: fmt.Errorf("failed to get rates: %w", &net.OpError{Op: "dial", Err: errors.New("connection refused")}), want: true},
+		"truncated body":  {err: fmt.Errorf("failed to decode rates API response: %w", io.ErrUnexpectedEOF), want: true},
+		"server error":    {err: status(http.StatusBadGateway), want: true},
+		"rate limited":    {err: status(http.StatusTooManyRequests), want: true},
+		"client error":    {err: status(http.StatusForbidden), want: false},
+		"decode error":    {err: fmt.Errorf("failed to decode rates API response: %w", errors.New("invalid character")), want: false},
+		"not found":       {err: fmt.Errorf("no rates: %w", internal.ErrRateNotFound), want: false},
+		"canceled":        {err: fmt.Errorf("failed to get rates: %w", context.Canceled), want: false},
+		"one source down": {err: fmt.Errorf("all sources failed: %w", errors.Join(status(http.StatusNotFound), status(http.StatusServiceUnavailable))), want: true},
+		"all sources 4xx": {err: fmt.Errorf("all sources failed: %w", errors.Join(status(http.StatusNotFound), status(http.StatusBadRequest))), want: false},
+	}
+
+	for name, tt := range tests {
+		if got := internal.IsRetryable(tt.err); got != tt.want {
+			t.Errorf("%s: IsRetryable(%v)=%v, want %v", name, tt.err, got, tt.want)
+		}
+	}
+}
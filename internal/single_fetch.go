@@ -0,0 +1,244 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func WithSingleFetch(base Currency, tolerance float64) SynchronizerOption {
+	return func(c *CurrencySynchronizer) {
+		c.fetchBase = base
+		c.crossRateTolerance = tolerance
+	}
+}
+
+func (c *CurrencySynchronizer) Plan(units []SyncUnit) []SyncUnit {
+	if c.fetchBase == "" {
+		return units
+	}
+
+	planned := make([]SyncUnit, 0, len(units))
+	index := make(map[string]int)
+
+	for _, unit := range units {
+		key := string(unit.Base) + "/" + dayKey(unit.Date)
+
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(planned)
+			planned = append(planned, SyncUnit{Base: unit.Base, Date: unit.Date, Currencies: slices.Clone(unit.Currencies)})
+			continue
+		}
+
+		switch {
+		case len(planned[i].Currencies) == 0:
+		case len(unit.Currencies) == 0:
+			planned[i].Currencies = nil
+		default:
+			for _, currency := range unit.Currencies {
+				if !slices.Contains(planned[i].Currencies, currency) {
+					planned[i].Currencies = append(planned[i].Currencies, currency)
+				}
+			}
+		}
+	}
+
+	return planned
+}
+
+type pivotFetches struct {
+	mu     sync.Mutex
+	byDate map[string]*pivotFetch
+}
+
+type pivotFetch struct {
+	once       sync.Once
+	fromPivot  map[Currency]CurrencyRate
+	sampleBase Currency
+	sample     map[Currency]CurrencyRate
+	err        error
+	fetchErr   error
+}
+
+func newPivotFetches() *pivotFetches {
+	return &pivotFetches{byDate: make(map[string]*pivotFetch)}
+}
+
+func (p *pivotFetches) get(date time.Time) *pivotFetch {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := dayKey(date)
+	if _, ok := p.byDate[key]; !ok {
+		p.byDate[key] = &pivotFetch{}
+	}
+
+	return p.byDate[key]
+}
+
+func (c *CurrencySynchronizer) fetchCrossRates(ctx context.Context, unit SyncUnit, pivots *pivotFetches) fetchedUnit {
+	result := fetchedUnit{result: SyncUnitResult{Unit: unit}}
+
+	fetch := pivots.get(unit.Date)
+	fetch.once.Do(func() {
+		c.fetchPivot(ctx, unit.Date, fetch)
+	})
+
+	if fetch.err != nil {
+		result.result.Err = fetch.err
+		return result
+	}
+	result.fetchErr = fetch.fetchErr
+
+	targets := unit.Currencies
+	if len(targets) == 0 {
+		targets = c.otherCurrencies(unit.Base)
+	}
+
+	var stored map[Currency]CurrencyRate
+	rates := make([]CurrencyRate, 0, len(targets))
+
+	for _, currency := range targets {
+		rate, ok := c.crossRate(unit.Base, currency, unit.Date, fetch.fromPivot)
+		if !ok {
+			continue
+		}
+
+		if rate.Derived {
+			if direct, ok := fetch.sample[currency]; ok && unit.Base == fetch.sampleBase {
+				if deviation := rate.Rate.Div(direct.Rate).Sub(decimal.NewFromInt(1)).Abs().InexactFloat64(); deviation > c.crossRateTolerance {
+					log.Printf("cross rate %s-%s for %s derived via %s is %v, directly fetched rate is %v (%.2f%% apart)",
+						rate.Base, rate.Currency, unit.Date.Format("2006-01-02"), c.fetchBase, rate.Rate, direct.Rate, deviation*100)
+				}
+
+				rates = append(rates, direct)
+				continue
+			}
+
+			if stored == nil {
+				var err error
+				stored, err = c.directRates(ctx, unit.Base, unit.Date)
+				if err != nil {
+					result.result.Err = err
+					return result
+				}
+			}
+
+			if _, ok := stored[currency]; ok {
+				continue
+			}
+		}
+
+		rates = append(rates, rate)
+	}
+
+	result.rates = rates
+
+	return result
+}
+
+func (c *CurrencySynchronizer) fetchPivot(ctx context.Context, date time.Time, fetch *pivotFetch) {
+	var fetched []CurrencyRate
+	err := c.retryPolicy.Do(ctx, func() error {
+		var err error
+		fetched, err = c.source.Get(ctx, c.fetchBase, c.otherCurrencies(c.fetchBase), date)
+		return err
+	})
+	if err != nil && !isPartial(err, fetched) {
+		fetch.err = fmt.Errorf("failed to get currency rates for %s for %s: %w", c.fetchBase, date.Format("2006-01-02"), err)
+		return
+	}
+	fetch.fetchErr = err
+
+	fetch.fromPivot = map[Currency]CurrencyRate{c.fetchBase: {Base: c.fetchBase, Currency: c.fetchBase, Rate: decimal.NewFromInt(1)}}
+	for _, rate := range fetched {
+		if !rate.Rate.IsZero() {
+			fetch.fromPivot[rate.Currency] = rate
+		}
+	}
+
+	fetch.sampleBase, fetch.sample = c.sampleDirectRates(ctx, date)
+}
+
+func (c *CurrencySynchronizer) sampleDirectRates(ctx context.Context, date time.Time) (Currency, map[Currency]CurrencyRate) {
+	bases := c.otherCurrencies(c.fetchBase)
+	if len(bases) == 0 {
+		return "", nil
+	}
+
+	base := bases[int(date.Unix()/86400)%len(bases)]
+
+	var fetched []CurrencyRate
+	err := c.retryPolicy.Do(ctx, func() error {
+		var err error
+		fetched, err = c.source.Get(ctx, base, c.otherCurrencies(base), date)
+		return err
+	})
+	if err != nil && !isPartial(err, fetched) {
+		log.Printf("failed to get direct %s rates for %s to check cross rates: %v", base, date.Format("2006-01-02"), err)
+		return "", nil
+	}
+
+	sample := make(map[Currency]CurrencyRate)
+	for _, rate := range fetched {
+		if !rate.Rate.IsZero() {
+			rate.Date = date
+			rate.Base = base
+			sample[rate.Currency] = rate
+		}
+	}
+
+	return base, sample
+}
+
+func (c *CurrencySynchronizer) crossRate(base Currency, currency Currency, date time.Time, fromPivot map[Currency]CurrencyRate) (CurrencyRate, bool) {
+	toBase, ok := fromPivot[base]
+	if !ok {
+		return CurrencyRate{}, false
+	}
+
+	toCurrency, ok := fromPivot[currency]
+	if !ok {
+		return CurrencyRate{}, false
+	}
+
+	provider := toCurrency.Provider
+	switch {
+	case currency == c.fetchBase:
+		provider = toBase.Provider
+	case base != c.fetchBase && toBase.Provider != toCurrency.Provider:
+		provider = toBase.Provider + "," + toCurrency.Provider
+	}
+
+	return CurrencyRate{
+		Date:     date,
+		Base:     base,
+		Currency: currency,
+		Rate:     toCurrency.Rate.Div(toBase.Rate),
+		Derived:  base != c.fetchBase || toCurrency.Derived,
+		Provider: provider,
+		Spread:   toBase.Spread.Add(toCurrency.Spread),
+	}, true
+}
+
+func (c *CurrencySynchronizer) directRates(ctx context.Context, base Currency, date time.Time) (map[Currency]CurrencyRate, error) {
+	stored, err := c.repository.GetMany(ctx, base, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check %s rates for %s against direct rates: %w", base, date.Format("2006-01-02"), err)
+	}
+
+	direct := make(map[Currency]CurrencyRate)
+	for _, rate := range stored {
+		if !rate.Derived && !rate.Rate.IsZero() {
+			direct[rate.Currency] = rate
+		}
+	}
+
+	return direct, nil
+}
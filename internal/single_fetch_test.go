@@ -0,0 +1,200 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/fedorov-dmitry/go-test-api/internal"
+	"github.com/fedorov-dmitry/go-test-api/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCurrencySynchronizer_SingleFetch_DerivesAllPairs(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockSource := mocks.NewMockCurrencyRateSource(ctrl)
+	mockStorage := mocks.NewMockCurrencyStorage(ctrl)
+	repo := internal.NewCurrencyRepository(mockStorage)
+
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+	jpy := internal.NewCurrency("jpy")
+	s := internal.NewCurrencySynchronizer(*repo, mockSource, []internal.Currency{usd, eur, jpy}, internal.WithSingleFetch(eur, 0.01))
+
+	date := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	units := []internal.SyncUnit{{Base: usd, Date: date}, {Base: eur, Date: date}, {Base: jpy, Date: date}}
+
+	mockSource.
+		EXPECT().
+		Get(gomock.Any(), eur, []internal.Currency{usd, jpy}, date).
+		Return([]internal.CurrencyRate{
+			{Currency: usd, Rate: dec("1.25"), Provider: "cdn"},
+			{Currency: jpy, Rate: dec("150"), Provider: "cdn"},
+		}, nil).
+		Times(1)
+
+	// usd is the base fetched directly on this date to check the cross rates against
+	mockSource.
+		EXPECT().
+		Get(gomock.Any(), usd, []internal.Currency{eur, jpy}, date).
+		Return([]internal.CurrencyRate{
+			{Currency: eur, Rate: dec("0.8"), Provider: "ecb"},
+			{Currency: jpy, Rate: dec("121"), Provider: "ecb"},
+		}, nil)
+
+	mockStorage.EXPECT().GetMany(ctx, jpy, date).Return([]internal.CurrencyRate{}, nil)
+
+	saved := make(map[[2]internal.Currency]internal.CurrencyRate)
+	mockStorage.
+		EXPECT().
+		SetMany(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, rates []internal.CurrencyRate) error {
+			for _, rate := range rates {
+				saved[[2]internal.Currency{rate.Base, rate.Currency}] = rate
+			}
+			return nil
+		})
+
+	report := s.Run(ctx, internal.SyncTriggerManual, units)
+	if err := report.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Succeeded) != 3 || report.RowsWritten() != 6 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	want := map[[2]internal.Currency]internal.CurrencyRate{
+		{eur, usd}: {Date: date, Base: eur, Currency: usd, Rate: dec("1.25"), Provider: "cdn"},
+		{eur, jpy}: {Date: date, Base: eur, Currency: jpy, Rate: dec("150"), Provider: "cdn"},
+		{usd, eur}: {Date: date, Base: usd, Currency: eur, Rate: dec("0.8"), Provider: "ecb"},
+		{usd, jpy}: {Date: date, Base: usd, Currency: jpy, Rate: dec("121"), Provider: "ecb"},
+		{jpy, eur}: {Date: date, Base: jpy, Currency: eur, Rate: dec("1.0").Div(dec("150")), Derived: true, Provider: "cdn"},
+		{jpy, usd}: {Date: date, Base: jpy, Currency: usd, Rate: dec("1.25").Div(dec("150")), Derived: true, Provider: "cdn"},
+	}
+	for key, rate := range want {
+		if !saved[key].Equal(rate) {
+			t.Fatalf("%s-%s: got %+v, want %+v", key[0], key[1], saved[key], rate)
+		}
+	}
+}
+
+func TestCurrencySynchronizer_SingleFetch_KeepsDirectRates(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockSource := mocks.NewMockCurrencyRateSource(ctrl)
+	mockStorage := mocks.NewMockCurrencyStorage(ctrl)
+	repo := internal.NewCurrencyRepository(mockStorage)
+
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+	s := internal.NewCurrencySynchronizer(*repo, mockSource, []internal.Currency{usd, eur}, internal.WithSingleFetch(eur, 0.01))
+
+	date := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	mockSource.
+		EXPECT().
+		Get(gomock.Any(), eur, []internal.Currency{usd}, date).
+		Return([]internal.CurrencyRate{{Currency: usd, Rate: dec("1.25")}}, nil)
+	mockSource.
+		EXPECT().
+		Get(gomock.Any(), usd, []internal.Currency{eur}, date).
+		Return(nil, errors.New("source down"))
+	mockStorage.
+		EXPECT().
+		GetMany(ctx, usd, date).
+		Return([]internal.CurrencyRate{{Date: date, Base: usd, Currency: eur, Rate: dec("0.7")}}, nil)
+	mockStorage.
+		EXPECT().
+		SetMany(ctx, equalRates(internal.CurrencyRate{Date: date, Base: eur, Currency: usd, Rate: dec("1.25")})).
+		Return(nil)
+
+	report := s.Run(ctx, internal.SyncTriggerManual, []internal.SyncUnit{{Base: usd, Date: date}, {Base: eur, Date: date}})
+	if err := report.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.RowsWritten() != 1 {
+		t.Fatalf("RowsWritten=%d, want 1", report.RowsWritten())
+	}
+}
+
+func TestCurrencySynchronizer_SingleFetch_StoresDirectRateOnMismatch(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockSource := mocks.NewMockCurrencyRateSource(ctrl)
+	mockStorage := mocks.NewMockCurrencyStorage(ctrl)
+	repo := internal.NewCurrencyRepository(mockStorage)
+
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+	s := internal.NewCurrencySynchronizer(*repo, mockSource, []internal.Currency{usd, eur}, internal.WithSingleFetch(eur, 0.01))
+
+	date := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	mockSource.
+		EXPECT().
+		Get(gomock.Any(), eur, []internal.Currency{usd}, date).
+		Return([]internal.CurrencyRate{{Currency: usd, Rate: dec("1.25")}}, nil)
+	mockSource.
+		EXPECT().
+		Get(gomock.Any(), usd, []internal.Currency{eur}, date).
+		Return([]internal.CurrencyRate{{Currency: eur, Rate: dec("0.7"), Provider: "ecb"}}, nil)
+	mockStorage.
+		EXPECT().
+		SetMany(ctx, equalRates(internal.CurrencyRate{Date: date, Base: usd, Currency: eur, Rate: dec("0.7"), Provider: "ecb"})).
+		Return(nil)
+
+	report := s.Run(ctx, internal.SyncTriggerManual, []internal.SyncUnit{{Base: usd, Date: date}})
+	if err := report.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.RowsWritten() != 1 {
+		t.Fatalf("RowsWritten=%d, want 1", report.RowsWritten())
+	}
+}
+
+func TestCurrencySynchronizer_Plan_KeepsUnitFilters(t *testing.T) {
+	t.Parallel()
+
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+	jpy := internal.NewCurrency("jpy")
+	s := internal.NewCurrencySynchronizer(internal.CurrencyRepository{}, nil, []internal.Currency{usd, eur, jpy}, internal.WithSingleFetch(usd, 0))
+
+	first := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	second := first.AddDate(0, 0, 1)
+
+	planned := s.Plan([]internal.SyncUnit{
+		{Base: eur, Date: first, Currencies: []internal.Currency{usd}},
+		{Base: eur, Date: first, Currencies: []internal.Currency{jpy}},
+		{Base: jpy, Date: second, Currencies: []internal.Currency{usd}},
+		{Base: jpy, Date: second},
+	})
+
+	want := []internal.SyncUnit{
+		{Base: eur, Date: first, Currencies: []internal.Currency{usd, jpy}},
+		{Base: jpy, Date: second},
+	}
+	if len(planned) != len(want) {
+		t.Fatalf("unexpected plan: %+v", planned)
+	}
+	for i := range want {
+		if planned[i].Base != want[i].Base || !planned[i].Date.Equal(want[i].Date) || !slices.Equal(planned[i].Currencies, want[i].Currencies) {
+			t.Fatalf("unit %d: got %+v, want %+v", i, planned[i], want[i])
+		}
+	}
+}
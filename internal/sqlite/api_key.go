@@ -0,0 +1,154 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/fedorov-dmitry/go-test-api/internal"
+)
+
+type ApiKeyStorage struct {
+	db *sql.DB
+}
+
+func NewApiKeyStorage(db *sql.DB) *ApiKeyStorage {
+	return &ApiKeyStorage{db: db}
+}
+
+func (s *ApiKeyStorage) Create(ctx context.Context, key internal.ApiKey) (int64, error) {
+	query := `
+INSERT INTO api_keys (name, prefix, key_hash, scopes, created_at, expires_at, revoked_at)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7)
+RETURNING id`
+
+	scopes, err := json.Marshal(key.Scopes)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	var id int64
+
+	err = s.db.QueryRowContext(ctx, query, key.Name, key.Prefix, key.Hash, string(scopes), formatTimestamp(key.CreatedAt),
+		formatNullTimestamp(key.ExpiresAt), formatNullTimestamp(key.RevokedAt)).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return id, nil
+}
+
+func (s *ApiKeyStorage) Get(ctx context.Context, id int64) (internal.ApiKey, error) {
+	query := `
+SELECT id, name, prefix, key_hash, scopes, created_at, expires_at, revoked_at FROM api_keys
+WHERE id = ?1`
+
+	key, err := scanApiKey(s.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		err = internal.ErrApiKeyNotFound
+	}
+	if err != nil {
+		return internal.ApiKey{}, fmt.Errorf("failed to get api key %d: %w", id, err)
+	}
+
+	return key, nil
+}
+
+func (s *ApiKeyStorage) GetByHash(ctx context.Context, hash string) (internal.ApiKey, error) {
+	query := `
+SELECT id, name, prefix, key_hash, scopes, created_at, expires_at, revoked_at FROM api_keys
+WHERE key_hash = ?1`
+
+	key, err := scanApiKey(s.db.QueryRowContext(ctx, query, hash))
+	if errors.Is(err, sql.ErrNoRows) {
+		err = internal.ErrApiKeyNotFound
+	}
+	if err != nil {
+		return internal.ApiKey{}, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	return key, nil
+}
+
+func (s *ApiKeyStorage) List(ctx context.Context) ([]internal.ApiKey, error) {
+	query := `
+SELECT id, name, prefix, key_hash, scopes, created_at, expires_at, revoked_at FROM api_keys
+ORDER BY id`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch api keys: %w", err)
+	}
+
+	keys := make([]internal.ApiKey, 0)
+
+	defer rows.Close()
+
+	for rows.Next() {
+		key, err := scanApiKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch api keys: %w", err)
+		}
+
+		keys = append(keys, key)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+func (s *ApiKeyStorage) Update(ctx context.Context, key internal.ApiKey) error {
+	query := `
+UPDATE api_keys
+SET prefix = ?2,
+    key_hash = ?3,
+    revoked_at = ?4
+WHERE id = ?1`
+
+	_, err := s.db.ExecContext(ctx, query, key.ID, key.Prefix, key.Hash, formatNullTimestamp(key.RevokedAt))
+	if err != nil {
+		return fmt.Errorf("failed to update api key %d: %w", key.ID, err)
+	}
+
+	return nil
+}
+
+func scanApiKey(row scanner) (internal.ApiKey, error) {
+	key := internal.ApiKey{}
+
+	var scopes, createdAt string
+	var expiresAt, revokedAt *string
+
+	err := row.Scan(&key.ID, &key.Name, &key.Prefix, &key.Hash, &scopes, &createdAt, &expiresAt, &revokedAt)
+	if err != nil {
+		return internal.ApiKey{}, err
+	}
+
+	err = json.Unmarshal([]byte(scopes), &key.Scopes)
+	if err != nil {
+		return internal.ApiKey{}, err
+	}
+
+	key.CreatedAt, err = parseTimestamp(createdAt)
+	if err != nil {
+		return internal.ApiKey{}, err
+	}
+
+	key.ExpiresAt, err = parseNullTimestamp(expiresAt)
+	if err != nil {
+		return internal.ApiKey{}, err
+	}
+
+	key.RevokedAt, err = parseNullTimestamp(revokedAt)
+	if err != nil {
+		return internal.ApiKey{}, err
+	}
+
+	return key, nil
+}
@@ -0,0 +1,79 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/fedorov-dmitry/go-test-api/internal"
+)
+
+type CurrencyCatalogStorage struct {
+	db *sql.DB
+}
+
+func NewCurrencyCatalogStorage(db *sql.DB) *CurrencyCatalogStorage {
+	return &CurrencyCatalogStorage{db: db}
+}
+
+func (s *CurrencyCatalogStorage) List(ctx context.Context) ([]internal.CurrencyInfo, error) {
+	query := `
+SELECT code, numeric_code, name, minor_units, active, kind FROM currencies
+ORDER BY code`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch currencies: %w", err)
+	}
+
+	currencies := make([]internal.CurrencyInfo, 0)
+
+	defer rows.Close()
+
+	for rows.Next() {
+		currency := internal.CurrencyInfo{}
+
+		err = rows.Scan(&currency.Code, &currency.NumericCode, &currency.Name, &currency.MinorUnits, &currency.Active, &currency.Kind)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch currencies: %w", err)
+		}
+
+		currencies = append(currencies, currency)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch currencies: %w", err)
+	}
+
+	return currencies, nil
+}
+
+func (s *CurrencyCatalogStorage) Seed(ctx context.Context, currencies []internal.CurrencyInfo) error {
+	query := `
+INSERT INTO currencies (code, numeric_code, name, minor_units, active, kind)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6)
+ON CONFLICT (code) DO NOTHING`
+
+	err := inTx(ctx, s.db, func(tx *sql.Tx) error {
+		stmt, err := tx.PrepareContext(ctx, query)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		for _, currency := range currencies {
+			_, err = stmt.ExecContext(ctx, currency.Code, currency.NumericCode, currency.Name, currency.MinorUnits, currency.Active, currency.Kind)
+			if err != nil {
+				return fmt.Errorf("failed to seed currency %s: %w", currency.Code, err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to seed %d currencies: %w", len(currencies), err)
+	}
+
+	return nil
+}
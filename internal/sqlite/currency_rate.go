@@ -0,0 +1,295 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fedorov-dmitry/go-test-api/internal"
+)
+
+type CurrencyStorage struct {
+	db *sql.DB
+}
+
+func NewCurrencyStorage(db *sql.DB) *CurrencyStorage {
+	return &CurrencyStorage{db: db}
+}
+
+func (c *CurrencyStorage) Get(ctx context.Context, baseCurrency internal.Currency, currency internal.Currency, date time.Time) (internal.CurrencyRate, error) {
+	source, args := ratesSource(ctx, baseCurrency, currency, formatDate(date))
+	query := `
+SELECT date, base, currency, rate, derived, provider, spread FROM ` + source + `
+WHERE base = ?1
+  AND currency = ?2
+  AND date = ?3`
+
+	rate, err := scanCurrencyRate(c.db.QueryRowContext(ctx, query, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		err = internal.ErrRateNotFound
+	}
+	if err != nil {
+		return internal.CurrencyRate{}, fmt.Errorf("failed to get currency rates for %s-%s: %w", baseCurrency, currency, err)
+	}
+
+	return rate, nil
+}
+
+func (c *CurrencyStorage) GetLatest(ctx context.Context, baseCurrency internal.Currency, currency internal.Currency, date time.Time) (internal.CurrencyRate, error) {
+	source, args := ratesSource(ctx, baseCurrency, currency, formatDate(date))
+	query := `
+SELECT date, base, currency, rate, derived, provider, spread FROM ` + source + `
+WHERE base = ?1
+  AND currency = ?2
+  AND date <= ?3
+ORDER BY date DESC
+LIMIT 1`
+
+	rate, err := scanCurrencyRate(c.db.QueryRowContext(ctx, query, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		err = internal.ErrRateNotFound
+	}
+	if err != nil {
+		return internal.CurrencyRate{}, fmt.Errorf("failed to get latest currency rate for %s-%s: %w", baseCurrency, currency, err)
+	}
+
+	return rate, nil
+}
+
+func (c *CurrencyStorage) GetLatestMany(ctx context.Context, baseCurrency internal.Currency, from time.Time, to time.Time) ([]internal.CurrencyRate, error) {
+	source, args := ratesSource(ctx, baseCurrency, formatDate(from), formatDate(to))
+	query := `
+SELECT date, base, currency, rate, derived, provider, spread FROM (
+    SELECT *, row_number() OVER (PARTITION BY currency ORDER BY date DESC) AS latest FROM ` + source + `
+    WHERE base = ?1
+      AND date >= ?2
+      AND date <= ?3
+)
+WHERE latest = 1
+ORDER BY currency`
+
+	rates, err := c.queryRates(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch latest currency rates for %s: %w", baseCurrency, err)
+	}
+
+	return rates, nil
+}
+
+func (c *CurrencyStorage) GetMany(ctx context.Context, baseCurrency internal.Currency, date time.Time) ([]internal.CurrencyRate, error) {
+	source, args := ratesSource(ctx, baseCurrency, formatDate(date))
+	query := `
+SELECT date, base, currency, rate, derived, provider, spread FROM ` + source + `
+WHERE base = ?1
+AND date = ?2`
+
+	rates, err := c.queryRates(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch currency rates for %s: %w", baseCurrency, err)
+	}
+
+	return rates, nil
+}
+
+func (c *CurrencyStorage) GetRange(ctx context.Context, baseCurrency internal.Currency, currency internal.Currency, from time.Time, to time.Time) ([]internal.CurrencyRate, error) {
+	source, args := ratesSource(ctx, baseCurrency, currency, formatDate(from), formatDate(to))
+	query := `
+SELECT date, base, currency, rate, derived, provider, spread FROM ` + source + `
+WHERE base = ?1
+  AND currency = ?2
+  AND date BETWEEN ?3 AND ?4
+ORDER BY date`
+
+	rates, err := c.queryRates(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch currency rates for %s-%s: %w", baseCurrency, currency, err)
+	}
+
+	return rates, nil
+}
+
+func (c *CurrencyStorage) GetKeys(ctx context.Context, from time.Time, to time.Time) ([]internal.CurrencyRateKey, error) {
+	query := `
+SELECT date, base, currency FROM currency_rates
+WHERE date BETWEEN ?1 AND ?2`
+
+	rows, err := c.db.QueryContext(ctx, query, formatDate(from), formatDate(to))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch stored currency rates: %w", err)
+	}
+
+	keys := make([]internal.CurrencyRateKey, 0)
+
+	defer rows.Close()
+
+	for rows.Next() {
+		key := internal.CurrencyRateKey{}
+
+		var date string
+		err = rows.Scan(&date, &key.Base, &key.Currency)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch stored currency rates: %w", err)
+		}
+
+		key.Date, err = parseDate(date)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch stored currency rates: %w", err)
+		}
+
+		keys = append(keys, key)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch stored currency rates: %w", err)
+	}
+
+	return keys, nil
+}
+
+const setCurrencyRateSQL = `
+INSERT INTO currency_rates (date, base, currency, rate, derived, provider, spread)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7)
+ON CONFLICT (date, base, currency)
+DO UPDATE SET
+   rate = excluded.rate,
+   derived = excluded.derived,
+   provider = excluded.provider,
+   spread = excluded.spread
+WHERE (currency_rates.rate, currency_rates.derived, currency_rates.provider, currency_rates.spread)
+    IS NOT (excluded.rate, excluded.derived, excluded.provider, excluded.spread)`
+
+func (c *CurrencyStorage) Set(ctx context.Context, rate internal.CurrencyRate) error {
+	_, err := c.db.ExecContext(ctx, setCurrencyRateSQL, formatDate(rate.Date), rate.Base, rate.Currency, rate.Rate, rate.Derived, rate.Provider, rate.Spread)
+	if err != nil {
+		return fmt.Errorf("failed to save currency rate for %s-%s: %w", rate.Base, rate.Currency, err)
+	}
+
+	return nil
+}
+
+func (c *CurrencyStorage) SetMany(ctx context.Context, rates []internal.CurrencyRate) error {
+	err := inTx(ctx, c.db, func(tx *sql.Tx) error {
+		stmt, err := tx.PrepareContext(ctx, setCurrencyRateSQL)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		for _, rate := range rates {
+			_, err = stmt.ExecContext(ctx, formatDate(rate.Date), rate.Base, rate.Currency, rate.Rate, rate.Derived, rate.Provider, rate.Spread)
+			if err != nil {
+				return fmt.Errorf("failed to save currency rate for %s-%s: %w", rate.Base, rate.Currency, err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save %d currency rates: %w", len(rates), err)
+	}
+
+	return nil
+}
+
+func (c *CurrencyStorage) GetRevisions(ctx context.Context, baseCurrency internal.Currency, currency internal.Currency, date time.Time) ([]internal.CurrencyRateRevision, error) {
+	query := `
+SELECT date, base, currency, rate, derived, provider, spread, recorded_at FROM currency_rate_revisions
+WHERE base = ?1
+  AND currency = ?2
+  AND date = ?3
+ORDER BY recorded_at`
+
+	rows, err := c.db.QueryContext(ctx, query, baseCurrency, currency, formatDate(date))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch currency rate revisions for %s-%s: %w", baseCurrency, currency, err)
+	}
+
+	revisions := make([]internal.CurrencyRateRevision, 0)
+
+	defer rows.Close()
+
+	for rows.Next() {
+		revision := internal.CurrencyRateRevision{}
+
+		var date, recordedAt string
+		err = rows.Scan(&date, &revision.Base, &revision.Currency, &revision.Rate, &revision.Derived, &revision.Provider, &revision.Spread, &recordedAt)
+		if err == nil {
+			revision.Date, err = parseDate(date)
+		}
+		if err == nil {
+			revision.RecordedAt, err = parseTimestamp(recordedAt)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch currency rate revisions for %s-%s: %w", baseCurrency, currency, err)
+		}
+
+		revisions = append(revisions, revision)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch currency rate revisions for %s-%s: %w", baseCurrency, currency, err)
+	}
+
+	return revisions, nil
+}
+
+func (c *CurrencyStorage) queryRates(ctx context.Context, query string, args ...any) ([]internal.CurrencyRate, error) {
+	rows, err := c.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	rates := make([]internal.CurrencyRate, 0)
+
+	defer rows.Close()
+
+	for rows.Next() {
+		rate, err := scanCurrencyRate(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		rates = append(rates, rate)
+	}
+
+	return rates, rows.Err()
+}
+
+func scanCurrencyRate(row scanner) (internal.CurrencyRate, error) {
+	rate := internal.CurrencyRate{}
+
+	var date string
+	err := row.Scan(&date, &rate.Base, &rate.Currency, &rate.Rate, &rate.Derived, &rate.Provider, &rate.Spread)
+	if err != nil {
+		return internal.CurrencyRate{}, err
+	}
+
+	rate.Date, err = parseDate(date)
+	if err != nil {
+		return internal.CurrencyRate{}, err
+	}
+
+	return rate, nil
+}
+
+func ratesSource(ctx context.Context, args ...any) (string, []any) {
+	asKnownAt, ok := internal.AsKnownAt(ctx)
+	if !ok {
+		return "currency_rates", args
+	}
+
+	args = append(args, formatTimestamp(asKnownAt))
+
+	return fmt.Sprintf(`(
+    SELECT date, base, currency, rate, derived, provider, spread FROM (
+        SELECT *, row_number() OVER (PARTITION BY date, base, currency ORDER BY recorded_at DESC) AS revision
+        FROM currency_rate_revisions
+        WHERE recorded_at <= ?%d
+    )
+    WHERE revision = 1
+) AS currency_rates`, len(args)), args
+}
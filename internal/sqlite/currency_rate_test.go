@@ -0,0 +1,360 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fedorov-dmitry/go-test-api/internal"
+	"github.com/shopspring/decimal"
+)
+
+func dec(value string) decimal.Decimal {
+	return decimal.RequireFromString(value)
+}
+
+var (
+	usd = internal.NewCurrency("usd")
+	eur = internal.NewCurrency("eur")
+	jpy = internal.NewCurrency("jpy")
+)
+
+func day(d int) time.Time {
+	return time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestCurrencyStorage_Queries(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := NewCurrencyStorage(newTestDB(t))
+
+	err := s.SetMany(ctx, []internal.CurrencyRate{
+		{Date: day(10), Base: usd, Currency: eur, Rate: dec("0.91")},
+		{Date: day(13), Base: usd, Currency: eur, Rate: dec("0.92"), Derived: true, Provider: "ecb", Spread: dec("0.001")},
+		{Date: day(15), Base: usd, Currency: eur, Rate: dec("0.93")},
+		{Date: day(13), Base: usd, Currency: jpy, Rate: dec("150")},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := s.Get(ctx, usd, eur, day(13))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := internal.CurrencyRate{Date: day(13), Base: usd, Currency: eur, Rate: dec("0.92"), Derived: true, Provider: "ecb", Spread: dec("0.001")}
+	if !got.Equal(want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+
+	got, err = s.GetLatest(ctx, usd, eur, time.Date(2025, 1, 14, 18, 30, 0, 0, time.UTC))
+	if err != nil || !got.Date.Equal(day(13)) {
+		t.Fatalf("got %+v, %v, want the rate from 2025-01-13", got, err)
+	}
+
+	_, err = s.GetLatest(ctx, usd, eur, day(9))
+	if !errors.Is(err, internal.ErrRateNotFound) {
+		t.Fatalf("expected ErrRateNotFound, got %v", err)
+	}
+
+	rates, err := s.GetRange(ctx, usd, eur, day(10), day(13))
+	if err != nil || len(rates) != 2 || !rates[0].Date.Equal(day(10)) || !rates[1].Date.Equal(day(13)) {
+		t.Fatalf("got %+v, %v", rates, err)
+	}
+
+	rates, err = s.GetMany(ctx, usd, day(13))
+	if err != nil || len(rates) != 2 {
+		t.Fatalf("got %+v, %v", rates, err)
+	}
+
+	rates, err = s.GetLatestMany(ctx, usd, day(11), day(14))
+	if err != nil || len(rates) != 2 || rates[0].Currency != eur || !rates[0].Date.Equal(day(13)) || rates[1].Currency != jpy {
+		t.Fatalf("got %+v, %v, want the latest eur and jpy rates up to 2025-01-14", rates, err)
+	}
+
+	keys, err := s.GetKeys(ctx, day(13), day(15))
+	if err != nil || len(keys) != 3 {
+		t.Fatalf("got %+v, %v", keys, err)
+	}
+}
+
+func TestCurrencyStorage_RevisionsAndAsKnownAt(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := NewCurrencyStorage(newTestDB(t))
+
+	first := internal.CurrencyRate{Date: day(13), Base: usd, Currency: eur, Rate: dec("0.92")}
+	if err := s.Set(ctx, first); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Identical re-sync does not add a revision
+	if err := s.Set(ctx, first); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	between := time.Now().UTC()
+	time.Sleep(5 * time.Millisecond)
+
+	if err := s.Set(ctx, internal.CurrencyRate{Date: day(13), Base: usd, Currency: eur, Rate: dec("0.95")}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	revisions, err := s.GetRevisions(ctx, usd, eur, day(13))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(revisions) != 2 || !revisions[0].Rate.Equal(dec("0.92")) || !revisions[1].Rate.Equal(dec("0.95")) {
+		t.Fatalf("unexpected revisions: %+v", revisions)
+	}
+
+	got, err := s.Get(ctx, usd, eur, day(13))
+	if err != nil || !got.Rate.Equal(dec("0.95")) {
+		t.Fatalf("got %+v, %v, want current rate 0.95", got, err)
+	}
+
+	got, err = s.Get(internal.WithAsKnownAt(ctx, between), usd, eur, day(13))
+	if err != nil || !got.Rate.Equal(dec("0.92")) {
+		t.Fatalf("got %+v, %v, want rate known at the time 0.92", got, err)
+	}
+
+	_, err = s.GetLatest(internal.WithAsKnownAt(ctx, revisions[0].RecordedAt.Add(-time.Second)), usd, eur, day(13))
+	if !errors.Is(err, internal.ErrRateNotFound) {
+		t.Fatalf("expected ErrRateNotFound before the first revision, got %v", err)
+	}
+}
+
+func TestSyncRunAndQuarantineStorage_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	runs := NewSyncRunStorage(db)
+	startedAt := time.Date(2025, 1, 13, 10, 0, 0, 0, time.UTC)
+
+	id, err := runs.Create(ctx, internal.SyncRun{
+		Trigger:   internal.SyncTriggerManual,
+		Status:    internal.SyncRunStatusRunning,
+		StartedAt: startedAt,
+		Bases:     []internal.Currency{usd},
+		Dates:     []time.Time{day(13)},
+		Errors:    []string{},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	finishedAt := startedAt.Add(time.Minute)
+	err = runs.Update(ctx, internal.SyncRun{ID: id, Status: internal.SyncRunStatusSucceeded, FinishedAt: &finishedAt, RowsWritten: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	run, err := runs.GetLastSucceeded(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if run.ID != id || !run.StartedAt.Equal(startedAt) || run.FinishedAt == nil || !run.FinishedAt.Equal(finishedAt) ||
+		run.RowsWritten != 3 || len(run.Bases) != 1 || len(run.Dates) != 1 || !run.Dates[0].Equal(day(13)) || len(run.Errors) != 0 {
+		t.Fatalf("unexpected run: %+v", run)
+	}
+
+	quarantine := NewQuarantineStorage(db)
+	rate := internal.QuarantinedRate{
+		Rate:         internal.CurrencyRate{Date: day(13), Base: usd, Currency: eur, Rate: dec("9.2")},
+		PreviousDate: day(12),
+		PreviousRate: dec("0.92"),
+		Change:       9,
+		Status:       internal.QuarantineStatusPending,
+		CreatedAt:    startedAt,
+	}
+
+	first, err := quarantine.Create(ctx, rate)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rate.Rate.Rate = dec("9.3")
+	second, err := quarantine.Create(ctx, rate)
+	if err != nil || second != first {
+		t.Fatalf("got %d, %v, want the pending entry %d to be replaced", second, err, first)
+	}
+
+	pending, err := quarantine.List(ctx, internal.QuarantineStatusPending, 10)
+	if err != nil || len(pending) != 1 || !pending[0].Rate.Rate.Equal(dec("9.3")) || !pending[0].PreviousDate.Equal(day(12)) {
+		t.Fatalf("got %+v, %v", pending, err)
+	}
+
+	_, err = quarantine.Get(ctx, first+1)
+	if !errors.Is(err, internal.ErrQuarantinedRateNotFound) {
+		t.Fatalf("expected ErrQuarantinedRateNotFound, got %v", err)
+	}
+
+	rejected := pending[0]
+	rejected.Status = internal.QuarantineStatusRejected
+	rejected.ResolvedAt = &finishedAt
+	err = quarantine.Update(ctx, rejected)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	found, err := quarantine.GetRejected(ctx, internal.CurrencyRate{Date: day(13), Base: usd, Currency: eur, Rate: dec("9.30")})
+	if err != nil || found.ID != first {
+		t.Fatalf("got %+v, %v, want rejected entry %d", found, err, first)
+	}
+
+	_, err = quarantine.GetRejected(ctx, internal.CurrencyRate{Date: day(13), Base: usd, Currency: eur, Rate: dec("9.2")})
+	if !errors.Is(err, internal.ErrQuarantinedRateNotFound) {
+		t.Fatalf("expected ErrQuarantinedRateNotFound, got %v", err)
+	}
+
+	rate.Rate = internal.CurrencyRate{Date: day(14), Base: usd, Currency: eur, Rate: dec("9.4")}
+	approved := rate
+	approved.ID, err = quarantine.Create(ctx, rate)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	approved.Status = internal.QuarantineStatusApproved
+	approved.ResolvedAt = &finishedAt
+	err = quarantine.Approve(ctx, approved)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	saved, err := NewCurrencyStorage(db).Get(ctx, usd, eur, day(14))
+	if err != nil || !saved.Rate.Equal(dec("9.4")) {
+		t.Fatalf("got %+v, %v, want the approved rate to be saved", saved, err)
+	}
+
+	err = quarantine.Approve(ctx, approved)
+	if !errors.Is(err, internal.ErrQuarantinedRateResolved) {
+		t.Fatalf("expected ErrQuarantinedRateResolved, got %v", err)
+	}
+}
+
+func TestCurrencyCatalogStorage_SeedKeepsExistingRows(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := NewCurrencyCatalogStorage(newTestDB(t))
+
+	err := s.Seed(ctx, []internal.CurrencyInfo{
+		{Code: usd, NumericCode: "840", Name: "US Dollar", MinorUnits: 2, Active: true, Kind: internal.CurrencyKindFiat},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = s.Seed(ctx, []internal.CurrencyInfo{
+		{Code: usd, NumericCode: "840", Name: "Renamed", MinorUnits: 4, Active: false, Kind: internal.CurrencyKindFiat},
+		{Code: "btc", Name: "Bitcoin", MinorUnits: 8, Active: true, Kind: internal.CurrencyKindCrypto},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	currencies, err := s.List(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []internal.CurrencyInfo{
+		{Code: "btc", Name: "Bitcoin", MinorUnits: 8, Active: true, Kind: internal.CurrencyKindCrypto},
+		{Code: usd, NumericCode: "840", Name: "US Dollar", MinorUnits: 2, Active: true, Kind: internal.CurrencyKindFiat},
+	}
+	if len(currencies) != len(want) || currencies[0] != want[0] || currencies[1] != want[1] {
+		t.Fatalf("got %+v, want %+v", currencies, want)
+	}
+}
+
+func TestTrackedCurrencyStorage_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := NewTrackedCurrencyStorage(newTestDB(t))
+
+	addedAt := time.Date(2025, 1, 13, 10, 0, 0, 0, time.UTC)
+	if err := s.Add(ctx, internal.TrackedCurrency{Currency: usd, AddedAt: addedAt}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.Add(ctx, internal.TrackedCurrency{Currency: "chf", AddedAt: addedAt.Add(time.Hour)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := s.Add(ctx, internal.TrackedCurrency{Currency: usd, AddedAt: addedAt.Add(2 * time.Hour)})
+	if !errors.Is(err, internal.ErrCurrencyAlreadyTracked) {
+		t.Fatalf("expected ErrCurrencyAlreadyTracked, got %v", err)
+	}
+
+	currencies, err := s.List(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(currencies) != 2 || currencies[0].Currency != usd || !currencies[0].AddedAt.Equal(addedAt) || currencies[1].Currency != "chf" {
+		t.Fatalf("unexpected currencies: %+v", currencies)
+	}
+
+	if err := s.Remove(ctx, usd); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = s.Remove(ctx, usd)
+	if !errors.Is(err, internal.ErrCurrencyNotTracked) {
+		t.Fatalf("expected ErrCurrencyNotTracked, got %v", err)
+	}
+}
+
+func TestApiKeyStorage_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	s := NewApiKeyStorage(newTestDB(t))
+
+	createdAt := time.Date(2025, 1, 13, 10, 0, 0, 0, time.UTC)
+	expiresAt := createdAt.AddDate(1, 0, 0)
+	id, err := s.Create(ctx, internal.ApiKey{
+		Name:      "billing",
+		Prefix:    "gta_0123abcd",
+		Hash:      internal.HashApiKey("gta_0123abcd"),
+		Scopes:    []internal.ApiKeyScope{internal.ApiKeyScopeReadRates, internal.ApiKeyScopeSync},
+		CreatedAt: createdAt,
+		ExpiresAt: &expiresAt,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	key, err := s.GetByHash(ctx, internal.HashApiKey("gta_0123abcd"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if key.ID != id || key.Name != "billing" || len(key.Scopes) != 2 || key.Scopes[1] != internal.ApiKeyScopeSync ||
+		!key.CreatedAt.Equal(createdAt) || key.ExpiresAt == nil || !key.ExpiresAt.Equal(expiresAt) || key.RevokedAt != nil {
+		t.Fatalf("unexpected key: %+v", key)
+	}
+
+	revokedAt := createdAt.Add(time.Hour)
+	key.Prefix = "gta_4567efgh"
+	key.Hash = internal.HashApiKey("gta_4567efgh")
+	key.RevokedAt = &revokedAt
+	if err := s.Update(ctx, key); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = s.GetByHash(ctx, internal.HashApiKey("gta_0123abcd"))
+	if !errors.Is(err, internal.ErrApiKeyNotFound) {
+		t.Fatalf("expected ErrApiKeyNotFound, got %v", err)
+	}
+
+	keys, err := s.List(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(keys) != 1 || keys[0].Prefix != "gta_4567efgh" || keys[0].RevokedAt == nil || !keys[0].RevokedAt.Equal(revokedAt) {
+		t.Fatalf("unexpected keys: %+v", keys)
+	}
+}
@@ -0,0 +1,157 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"time"
+
+	"github.com/fedorov-dmitry/go-test-api/internal/migration"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+type Migrator struct {
+	db         *sql.DB
+	migrations []migration.Migration
+}
+
+func NewMigrator(db *sql.DB) (*Migrator, error) {
+	sub, err := fs.Sub(migrationFiles, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embedded migrations: %w", err)
+	}
+
+	migrations, err := migration.Load(sub)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+func (m *Migrator) Up(ctx context.Context) ([]migration.Migration, error) {
+	applied := make([]migration.Migration, 0)
+
+	versions, err := m.versions(ctx)
+	if err != nil {
+		return applied, err
+	}
+
+	for _, pending := range m.migrations {
+		if _, ok := versions[pending.Version]; ok {
+			continue
+		}
+
+		err = inTx(ctx, m.db, func(tx *sql.Tx) error {
+			_, err := tx.ExecContext(ctx, pending.Up)
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, name, applied_at) VALUES (?1, ?2, ?3)`,
+				pending.Version, pending.Name, formatTimestamp(time.Now()))
+			return err
+		})
+		if err != nil {
+			return applied, fmt.Errorf("failed to apply migration %04d_%s: %w", pending.Version, pending.Name, err)
+		}
+
+		applied = append(applied, pending)
+	}
+
+	return applied, nil
+}
+
+func (m *Migrator) Down(ctx context.Context, steps int) ([]migration.Migration, error) {
+	reverted := make([]migration.Migration, 0)
+
+	versions, err := m.versions(ctx)
+	if err != nil {
+		return reverted, err
+	}
+
+	for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+		applied := m.migrations[i]
+		if _, ok := versions[applied.Version]; !ok {
+			continue
+		}
+
+		if applied.Down == "" {
+			return reverted, fmt.Errorf("migration %04d_%s cannot be reverted: no down migration", applied.Version, applied.Name)
+		}
+
+		err = inTx(ctx, m.db, func(tx *sql.Tx) error {
+			_, err := tx.ExecContext(ctx, applied.Down)
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = ?1`, applied.Version)
+			return err
+		})
+		if err != nil {
+			return reverted, fmt.Errorf("failed to revert migration %04d_%s: %w", applied.Version, applied.Name, err)
+		}
+
+		reverted = append(reverted, applied)
+	}
+
+	return reverted, nil
+}
+
+func (m *Migrator) Status(ctx context.Context) ([]migration.Status, error) {
+	versions, err := m.versions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return migration.Statuses(m.migrations, versions), nil
+}
+
+func (m *Migrator) versions(ctx context.Context) (map[int]time.Time, error) {
+	_, err := m.db.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS schema_migrations
+(
+    version    integer PRIMARY KEY,
+    name       text    NOT NULL,
+    applied_at text    NOT NULL
+);`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migrations table: %w", err)
+	}
+
+	rows, err := m.db.QueryContext(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch applied migrations: %w", err)
+	}
+
+	versions := make(map[int]time.Time)
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var version int
+		var appliedAt string
+
+		err = rows.Scan(&version, &appliedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch applied migrations: %w", err)
+		}
+
+		versions[version], err = parseTimestamp(appliedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch applied migrations: %w", err)
+		}
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch applied migrations: %w", err)
+	}
+
+	return versions, nil
+}
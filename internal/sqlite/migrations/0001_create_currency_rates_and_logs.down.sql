@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS logs;
+DROP TABLE IF EXISTS currency_rates;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS currency_rates
+(
+    date     text NOT NULL,
+    base     text NOT NULL,
+    currency text NOT NULL,
+    rate     real NOT NULL,
+    PRIMARY KEY (date, base, currency)
+);
+
+CREATE TABLE IF NOT EXISTS logs
+(
+    timestamp text NOT NULL,
+    path      text NOT NULL
+);
@@ -0,0 +1,3 @@
+ALTER TABLE currency_rates DROP COLUMN spread;
+ALTER TABLE currency_rates DROP COLUMN provider;
+ALTER TABLE currency_rates DROP COLUMN derived;
@@ -0,0 +1,3 @@
+ALTER TABLE currency_rates ADD COLUMN derived integer NOT NULL DEFAULT 0;
+ALTER TABLE currency_rates ADD COLUMN provider text NOT NULL DEFAULT '';
+ALTER TABLE currency_rates ADD COLUMN spread real NOT NULL DEFAULT 0;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS sync_runs
+(
+    id           integer PRIMARY KEY AUTOINCREMENT,
+    trigger      text    NOT NULL,
+    status       text    NOT NULL,
+    started_at   text    NOT NULL,
+    finished_at  text,
+    bases        text    NOT NULL DEFAULT '[]',
+    dates        text    NOT NULL DEFAULT '[]',
+    rows_written integer NOT NULL DEFAULT 0,
+    errors       text    NOT NULL DEFAULT '[]'
+);
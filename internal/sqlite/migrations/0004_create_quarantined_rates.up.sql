@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS quarantined_rates
+(
+    id            integer PRIMARY KEY AUTOINCREMENT,
+    date          text    NOT NULL,
+    base          text    NOT NULL,
+    currency      text    NOT NULL,
+    rate          real    NOT NULL,
+    derived       integer NOT NULL DEFAULT 0,
+    provider      text    NOT NULL DEFAULT '',
+    spread        real    NOT NULL DEFAULT 0,
+    previous_date text    NOT NULL,
+    previous_rate real    NOT NULL,
+    change        real    NOT NULL,
+    status        text    NOT NULL,
+    created_at    text    NOT NULL,
+    resolved_at   text
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS quarantined_rates_pending_idx
+    ON quarantined_rates (date, base, currency)
+    WHERE status = 'pending';
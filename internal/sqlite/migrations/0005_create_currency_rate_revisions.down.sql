@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS currency_rates_revision_update;
+DROP TRIGGER IF EXISTS currency_rates_revision_insert;
+DROP TABLE IF EXISTS currency_rate_revisions;
@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS currency_rate_revisions
+(
+    date        text    NOT NULL,
+    base        text    NOT NULL,
+    currency    text    NOT NULL,
+    rate        real    NOT NULL,
+    derived     integer NOT NULL DEFAULT 0,
+    provider    text    NOT NULL DEFAULT '',
+    spread      real    NOT NULL DEFAULT 0,
+    recorded_at text    NOT NULL,
+    PRIMARY KEY (date, base, currency, recorded_at)
+);
+
+INSERT OR IGNORE INTO currency_rate_revisions (date, base, currency, rate, derived, provider, spread, recorded_at)
+SELECT date, base, currency, rate, derived, provider, spread, strftime('%Y-%m-%dT%H:%M:%fZ', 'now') FROM currency_rates;
+
+CREATE TRIGGER IF NOT EXISTS currency_rates_revision_insert
+AFTER INSERT ON currency_rates
+BEGIN
+    INSERT INTO currency_rate_revisions (date, base, currency, rate, derived, provider, spread, recorded_at)
+    VALUES (NEW.date, NEW.base, NEW.currency, NEW.rate, NEW.derived, NEW.provider, NEW.spread, strftime('%Y-%m-%dT%H:%M:%fZ', 'now'));
+END;
+
+CREATE TRIGGER IF NOT EXISTS currency_rates_revision_update
+AFTER UPDATE ON currency_rates
+BEGIN
+    INSERT INTO currency_rate_revisions (date, base, currency, rate, derived, provider, spread, recorded_at)
+    VALUES (NEW.date, NEW.base, NEW.currency, NEW.rate, NEW.derived, NEW.provider, NEW.spread, strftime('%Y-%m-%dT%H:%M:%fZ', 'now'));
+END;
@@ -0,0 +1,82 @@
+DROP TRIGGER IF EXISTS currency_rates_revision_update;
+DROP TRIGGER IF EXISTS currency_rates_revision_insert;
+
+CREATE TABLE currency_rates_new
+(
+    date     text    NOT NULL,
+    base     text    NOT NULL,
+    currency text    NOT NULL,
+    rate     text    NOT NULL,
+    derived  integer NOT NULL DEFAULT 0,
+    provider text    NOT NULL DEFAULT '',
+    spread   text    NOT NULL DEFAULT '0',
+    PRIMARY KEY (date, base, currency)
+);
+
+INSERT INTO currency_rates_new (date, base, currency, rate, derived, provider, spread)
+SELECT date, base, currency, CAST(rate AS text), derived, provider, CAST(spread AS text) FROM currency_rates;
+
+DROP TABLE currency_rates;
+ALTER TABLE currency_rates_new RENAME TO currency_rates;
+
+CREATE TABLE currency_rate_revisions_new
+(
+    date        text    NOT NULL,
+    base        text    NOT NULL,
+    currency    text    NOT NULL,
+    rate        text    NOT NULL,
+    derived     integer NOT NULL DEFAULT 0,
+    provider    text    NOT NULL DEFAULT '',
+    spread      text    NOT NULL DEFAULT '0',
+    recorded_at text    NOT NULL,
+    PRIMARY KEY (date, base, currency, recorded_at)
+);
+
+INSERT INTO currency_rate_revisions_new (date, base, currency, rate, derived, provider, spread, recorded_at)
+SELECT date, base, currency, CAST(rate AS text), derived, provider, CAST(spread AS text), recorded_at FROM currency_rate_revisions;
+
+DROP TABLE currency_rate_revisions;
+ALTER TABLE currency_rate_revisions_new RENAME TO currency_rate_revisions;
+
+CREATE TABLE quarantined_rates_new
+(
+    id            integer PRIMARY KEY AUTOINCREMENT,
+    date          text    NOT NULL,
+    base          text    NOT NULL,
+    currency      text    NOT NULL,
+    rate          text    NOT NULL,
+    derived       integer NOT NULL DEFAULT 0,
+    provider      text    NOT NULL DEFAULT '',
+    spread        text    NOT NULL DEFAULT '0',
+    previous_date text    NOT NULL,
+    previous_rate text    NOT NULL,
+    change        real    NOT NULL,
+    status        text    NOT NULL,
+    created_at    text    NOT NULL,
+    resolved_at   text
+);
+
+INSERT INTO quarantined_rates_new (id, date, base, currency, rate, derived, provider, spread, previous_date, previous_rate, change, status, created_at, resolved_at)
+SELECT id, date, base, currency, CAST(rate AS text), derived, provider, CAST(spread AS text), previous_date, CAST(previous_rate AS text), change, status, created_at, resolved_at
+FROM quarantined_rates;
+
+DROP TABLE quarantined_rates;
+ALTER TABLE quarantined_rates_new RENAME TO quarantined_rates;
+
+CREATE UNIQUE INDEX IF NOT EXISTS quarantined_rates_pending_idx
+    ON quarantined_rates (date, base, currency)
+    WHERE status = 'pending';
+
+CREATE TRIGGER IF NOT EXISTS currency_rates_revision_insert
+AFTER INSERT ON currency_rates
+BEGIN
+    INSERT INTO currency_rate_revisions (date, base, currency, rate, derived, provider, spread, recorded_at)
+    VALUES (NEW.date, NEW.base, NEW.currency, NEW.rate, NEW.derived, NEW.provider, NEW.spread, strftime('%Y-%m-%dT%H:%M:%fZ', 'now'));
+END;
+
+CREATE TRIGGER IF NOT EXISTS currency_rates_revision_update
+AFTER UPDATE ON currency_rates
+BEGIN
+    INSERT INTO currency_rate_revisions (date, base, currency, rate, derived, provider, spread, recorded_at)
+    VALUES (NEW.date, NEW.base, NEW.currency, NEW.rate, NEW.derived, NEW.provider, NEW.spread, strftime('%Y-%m-%dT%H:%M:%fZ', 'now'));
+END;
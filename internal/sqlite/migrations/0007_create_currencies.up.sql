@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS currencies
+(
+    code         text    PRIMARY KEY,
+    numeric_code text    NOT NULL DEFAULT '',
+    name         text    NOT NULL,
+    minor_units  integer NOT NULL,
+    active       integer NOT NULL DEFAULT 1,
+    kind         text    NOT NULL
+);
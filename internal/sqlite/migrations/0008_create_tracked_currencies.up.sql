@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS tracked_currencies
+(
+    currency text PRIMARY KEY,
+    added_at text NOT NULL
+);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS api_keys
+(
+    id         integer PRIMARY KEY AUTOINCREMENT,
+    name       text    NOT NULL,
+    prefix     text    NOT NULL,
+    key_hash   text    NOT NULL UNIQUE,
+    scopes     text    NOT NULL DEFAULT '[]',
+    created_at text    NOT NULL,
+    expires_at text,
+    revoked_at text
+);
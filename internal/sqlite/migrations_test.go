@@ -0,0 +1,94 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"github.com/fedorov-dmitry/go-test-api/internal/migration"
+)
+
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := Open(filepath.Join(t.TempDir(), "rates.db"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = migrator.Up(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return db
+}
+
+func TestLoadMigrations_Embedded(t *testing.T) {
+	t.Parallel()
+
+	sub, err := fs.Sub(migrationFiles, "migrations")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	migrations, err := migration.Load(sub)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Versions are shared between backends, so a backend skips the versions of migrations it does not need
+	for i, m := range migrations {
+		if i == 0 && m.Version != 1 || i > 0 && m.Version <= migrations[i-1].Version {
+			t.Fatalf("migration %s has version %d, want versions starting at 1 and increasing", m.Name, m.Version)
+		}
+		if m.Down == "" {
+			t.Fatalf("migration %04d_%s has no down migration", m.Version, m.Name)
+		}
+	}
+}
+
+func TestMigrator_UpDownStatus(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	applied, err := migrator.Up(ctx)
+	if err != nil || len(applied) != 0 {
+		t.Fatalf("got %+v, %v, want nothing left to apply", applied, err)
+	}
+
+	reverted, err := migrator.Down(ctx, len(migrator.migrations))
+	if err != nil || len(reverted) != len(migrator.migrations) {
+		t.Fatalf("got %+v, %v, want all migrations reverted", reverted, err)
+	}
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, status := range statuses {
+		if status.AppliedAt != nil {
+			t.Fatalf("migration %04d_%s is still applied", status.Version, status.Name)
+		}
+	}
+
+	applied, err = migrator.Up(ctx)
+	if err != nil || len(applied) != len(migrator.migrations) {
+		t.Fatalf("got %+v, %v, want all migrations applied again", applied, err)
+	}
+}
@@ -0,0 +1,213 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/fedorov-dmitry/go-test-api/internal"
+)
+
+type QuarantineStorage struct {
+	db *sql.DB
+}
+
+func NewQuarantineStorage(db *sql.DB) *QuarantineStorage {
+	return &QuarantineStorage{db: db}
+}
+
+func (s *QuarantineStorage) Create(ctx context.Context, rate internal.QuarantinedRate) (int64, error) {
+	query := `
+INSERT INTO quarantined_rates (date, base, currency, rate, derived, provider, spread, previous_date, previous_rate, change, status, created_at)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11, ?12)
+ON CONFLICT (date, base, currency) WHERE status = 'pending'
+DO UPDATE SET
+   rate = excluded.rate,
+   derived = excluded.derived,
+   provider = excluded.provider,
+   spread = excluded.spread,
+   previous_date = excluded.previous_date,
+   previous_rate = excluded.previous_rate,
+   change = excluded.change
+RETURNING id`
+
+	var id int64
+
+	err := s.db.QueryRowContext(ctx, query,
+		formatDate(rate.Rate.Date), rate.Rate.Base, rate.Rate.Currency, rate.Rate.Rate, rate.Rate.Derived, rate.Rate.Provider, rate.Rate.Spread,
+		formatDate(rate.PreviousDate), rate.PreviousRate, rate.Change, rate.Status, formatTimestamp(rate.CreatedAt),
+	).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create quarantined rate: %w", err)
+	}
+
+	return id, nil
+}
+
+func (s *QuarantineStorage) Get(ctx context.Context, id int64) (internal.QuarantinedRate, error) {
+	query := `
+SELECT id, date, base, currency, rate, derived, provider, spread, previous_date, previous_rate, change, status, created_at, resolved_at FROM quarantined_rates
+WHERE id = ?1`
+
+	rate, err := scanQuarantinedRate(s.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		err = internal.ErrQuarantinedRateNotFound
+	}
+	if err != nil {
+		return internal.QuarantinedRate{}, fmt.Errorf("failed to get quarantined rate %d: %w", id, err)
+	}
+
+	return rate, nil
+}
+
+func (s *QuarantineStorage) List(ctx context.Context, status internal.QuarantineStatus, limit int) ([]internal.QuarantinedRate, error) {
+	query := `
+SELECT id, date, base, currency, rate, derived, provider, spread, previous_date, previous_rate, change, status, created_at, resolved_at FROM quarantined_rates
+WHERE ?1 = '' OR status = ?1
+ORDER BY created_at DESC, id DESC
+LIMIT ?2`
+
+	rows, err := s.db.QueryContext(ctx, query, status, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch quarantined rates: %w", err)
+	}
+
+	rates := make([]internal.QuarantinedRate, 0)
+
+	defer rows.Close()
+
+	for rows.Next() {
+		rate, err := scanQuarantinedRate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch quarantined rates: %w", err)
+		}
+
+		rates = append(rates, rate)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch quarantined rates: %w", err)
+	}
+
+	return rates, nil
+}
+
+func (s *QuarantineStorage) Update(ctx context.Context, rate internal.QuarantinedRate) error {
+	query := `
+UPDATE quarantined_rates
+SET status = ?2,
+    resolved_at = ?3
+WHERE id = ?1`
+
+	_, err := s.db.ExecContext(ctx, query, rate.ID, rate.Status, formatNullTimestamp(rate.ResolvedAt))
+	if err != nil {
+		return fmt.Errorf("failed to update quarantined rate %d: %w", rate.ID, err)
+	}
+
+	return nil
+}
+
+func (s *QuarantineStorage) GetRejected(ctx context.Context, rate internal.CurrencyRate) (internal.QuarantinedRate, error) {
+	query := `
+SELECT id, date, base, currency, rate, derived, provider, spread, previous_date, previous_rate, change, status, created_at, resolved_at FROM quarantined_rates
+WHERE date = ?1
+  AND base = ?2
+  AND currency = ?3
+  AND status = 'rejected'
+ORDER BY resolved_at DESC, id DESC`
+
+	rows, err := s.db.QueryContext(ctx, query, formatDate(rate.Date), rate.Base, rate.Currency)
+	if err != nil {
+		return internal.QuarantinedRate{}, fmt.Errorf("failed to get rejected rate for %s-%s: %w", rate.Base, rate.Currency, err)
+	}
+
+	defer rows.Close()
+
+	// rates are stored as text, so compare them as decimals rather than in SQL
+	for rows.Next() {
+		rejected, err := scanQuarantinedRate(rows)
+		if err != nil {
+			return internal.QuarantinedRate{}, fmt.Errorf("failed to get rejected rate for %s-%s: %w", rate.Base, rate.Currency, err)
+		}
+
+		if rejected.Rate.Rate.Equal(rate.Rate) {
+			return rejected, nil
+		}
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return internal.QuarantinedRate{}, fmt.Errorf("failed to get rejected rate for %s-%s: %w", rate.Base, rate.Currency, err)
+	}
+
+	return internal.QuarantinedRate{}, fmt.Errorf("failed to get rejected rate for %s-%s: %w", rate.Base, rate.Currency, internal.ErrQuarantinedRateNotFound)
+}
+
+func (s *QuarantineStorage) Approve(ctx context.Context, rate internal.QuarantinedRate) error {
+	query := `
+UPDATE quarantined_rates
+SET status = ?2,
+    resolved_at = ?3
+WHERE id = ?1
+  AND status = 'pending'`
+
+	err := inTx(ctx, s.db, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, query, rate.ID, rate.Status, formatNullTimestamp(rate.ResolvedAt))
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return internal.ErrQuarantinedRateResolved
+		}
+
+		_, err = tx.ExecContext(ctx, setCurrencyRateSQL, formatDate(rate.Rate.Date), rate.Rate.Base, rate.Rate.Currency, rate.Rate.Rate, rate.Rate.Derived, rate.Rate.Provider, rate.Rate.Spread)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to approve quarantined rate %d: %w", rate.ID, err)
+	}
+
+	return nil
+}
+
+func scanQuarantinedRate(row scanner) (internal.QuarantinedRate, error) {
+	rate := internal.QuarantinedRate{}
+
+	var date, previousDate, createdAt string
+	var resolvedAt *string
+
+	err := row.Scan(&rate.ID, &date, &rate.Rate.Base, &rate.Rate.Currency, &rate.Rate.Rate, &rate.Rate.Derived, &rate.Rate.Provider, &rate.Rate.Spread,
+		&previousDate, &rate.PreviousRate, &rate.Change, &rate.Status, &createdAt, &resolvedAt)
+	if err != nil {
+		return internal.QuarantinedRate{}, err
+	}
+
+	rate.Rate.Date, err = parseDate(date)
+	if err != nil {
+		return internal.QuarantinedRate{}, err
+	}
+
+	rate.PreviousDate, err = parseDate(previousDate)
+	if err != nil {
+		return internal.QuarantinedRate{}, err
+	}
+
+	rate.CreatedAt, err = parseTimestamp(createdAt)
+	if err != nil {
+		return internal.QuarantinedRate{}, err
+	}
+
+	rate.ResolvedAt, err = parseNullTimestamp(resolvedAt)
+	if err != nil {
+		return internal.QuarantinedRate{}, err
+	}
+
+	return rate, nil
+}
@@ -0,0 +1,28 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/fedorov-dmitry/go-test-api/internal/middleware"
+)
+
+type RequestLogStorage struct {
+	db *sql.DB
+}
+
+func NewRequestLogStorage(db *sql.DB) *RequestLogStorage {
+	return &RequestLogStorage{db: db}
+}
+
+func (s *RequestLogStorage) Add(ctx context.Context, entry middleware.RequestLog) error {
+	query := "INSERT INTO logs (timestamp, path) VALUES (?1, ?2)"
+
+	_, err := s.db.ExecContext(ctx, query, formatTimestamp(entry.Timestamp), entry.Path)
+	if err != nil {
+		return fmt.Errorf("failed to insert log entry: %w", err)
+	}
+
+	return nil
+}
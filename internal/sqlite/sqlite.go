@@ -0,0 +1,89 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+const (
+	dateLayout      = "2006-01-02"
+	timestampLayout = "2006-01-02T15:04:05.000Z"
+)
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func Open(path string) (*sql.DB, error) {
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+
+	db, err := sql.Open("sqlite", path+separator+"_txlock=immediate&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite database %s: %w", path, err)
+	}
+
+	db.SetMaxOpenConns(1)
+
+	return db, nil
+}
+
+func inTx(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	err = fn(tx)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func formatDate(date time.Time) string {
+	return date.Format(dateLayout)
+}
+
+func parseDate(value string) (time.Time, error) {
+	return time.Parse(dateLayout, value)
+}
+
+func formatTimestamp(timestamp time.Time) string {
+	return timestamp.UTC().Format(timestampLayout)
+}
+
+func formatNullTimestamp(timestamp *time.Time) *string {
+	if timestamp == nil {
+		return nil
+	}
+
+	value := formatTimestamp(*timestamp)
+	return &value
+}
+
+func parseTimestamp(value string) (time.Time, error) {
+	return time.Parse(timestampLayout, value)
+}
+
+func parseNullTimestamp(value *string) (*time.Time, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	timestamp, err := parseTimestamp(*value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &timestamp, nil
+}
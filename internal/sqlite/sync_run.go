@@ -0,0 +1,186 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fedorov-dmitry/go-test-api/internal"
+)
+
+type SyncRunStorage struct {
+	db *sql.DB
+}
+
+func NewSyncRunStorage(db *sql.DB) *SyncRunStorage {
+	return &SyncRunStorage{db: db}
+}
+
+func (s *SyncRunStorage) Create(ctx context.Context, run internal.SyncRun) (int64, error) {
+	query := `
+INSERT INTO sync_runs (trigger, status, started_at, finished_at, bases, dates, rows_written, errors)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8)
+RETURNING id`
+
+	dates := make([]string, len(run.Dates))
+	for i, date := range run.Dates {
+		dates[i] = formatDate(date)
+	}
+
+	bases, err := json.Marshal(run.Bases)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create sync run: %w", err)
+	}
+
+	datesJSON, err := json.Marshal(dates)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create sync run: %w", err)
+	}
+
+	errs, err := marshalErrors(run.Errors)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create sync run: %w", err)
+	}
+
+	var id int64
+
+	err = s.db.QueryRowContext(ctx, query, run.Trigger, run.Status, formatTimestamp(run.StartedAt), formatNullTimestamp(run.FinishedAt),
+		string(bases), string(datesJSON), run.RowsWritten, errs).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create sync run: %w", err)
+	}
+
+	return id, nil
+}
+
+func (s *SyncRunStorage) Update(ctx context.Context, run internal.SyncRun) error {
+	query := `
+UPDATE sync_runs
+SET status = ?2,
+    finished_at = ?3,
+    rows_written = ?4,
+    errors = ?5
+WHERE id = ?1`
+
+	errs, err := marshalErrors(run.Errors)
+	if err != nil {
+		return fmt.Errorf("failed to update sync run %d: %w", run.ID, err)
+	}
+
+	_, err = s.db.ExecContext(ctx, query, run.ID, run.Status, formatNullTimestamp(run.FinishedAt), run.RowsWritten, errs)
+	if err != nil {
+		return fmt.Errorf("failed to update sync run %d: %w", run.ID, err)
+	}
+
+	return nil
+}
+
+func (s *SyncRunStorage) List(ctx context.Context, limit int) ([]internal.SyncRun, error) {
+	query := `
+SELECT id, trigger, status, started_at, finished_at, bases, dates, rows_written, errors FROM sync_runs
+ORDER BY started_at DESC, id DESC
+LIMIT ?1`
+
+	rows, err := s.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch sync runs: %w", err)
+	}
+
+	runs := make([]internal.SyncRun, 0)
+
+	defer rows.Close()
+
+	for rows.Next() {
+		run, err := scanSyncRun(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch sync runs: %w", err)
+		}
+
+		runs = append(runs, run)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch sync runs: %w", err)
+	}
+
+	return runs, nil
+}
+
+func (s *SyncRunStorage) GetLastSucceeded(ctx context.Context) (internal.SyncRun, error) {
+	query := `
+SELECT id, trigger, status, started_at, finished_at, bases, dates, rows_written, errors FROM sync_runs
+WHERE status = ?1
+ORDER BY finished_at DESC, id DESC
+LIMIT 1`
+
+	run, err := scanSyncRun(s.db.QueryRowContext(ctx, query, internal.SyncRunStatusSucceeded))
+	if errors.Is(err, sql.ErrNoRows) {
+		err = internal.ErrSyncRunNotFound
+	}
+	if err != nil {
+		return internal.SyncRun{}, fmt.Errorf("failed to get last successful sync run: %w", err)
+	}
+
+	return run, nil
+}
+
+func scanSyncRun(row scanner) (internal.SyncRun, error) {
+	run := internal.SyncRun{}
+
+	var startedAt, bases, dates, errs string
+	var finishedAt *string
+
+	err := row.Scan(&run.ID, &run.Trigger, &run.Status, &startedAt, &finishedAt, &bases, &dates, &run.RowsWritten, &errs)
+	if err != nil {
+		return internal.SyncRun{}, err
+	}
+
+	run.StartedAt, err = parseTimestamp(startedAt)
+	if err != nil {
+		return internal.SyncRun{}, err
+	}
+
+	run.FinishedAt, err = parseNullTimestamp(finishedAt)
+	if err != nil {
+		return internal.SyncRun{}, err
+	}
+
+	err = json.Unmarshal([]byte(bases), &run.Bases)
+	if err != nil {
+		return internal.SyncRun{}, err
+	}
+
+	err = json.Unmarshal([]byte(errs), &run.Errors)
+	if err != nil {
+		return internal.SyncRun{}, err
+	}
+
+	var days []string
+	err = json.Unmarshal([]byte(dates), &days)
+	if err != nil {
+		return internal.SyncRun{}, err
+	}
+
+	run.Dates = make([]time.Time, len(days))
+	for i, day := range days {
+		run.Dates[i], err = parseDate(day)
+		if err != nil {
+			return internal.SyncRun{}, err
+		}
+	}
+
+	return run, nil
+}
+
+func marshalErrors(errs []string) (string, error) {
+	if errs == nil {
+		errs = make([]string, 0)
+	}
+
+	data, err := json.Marshal(errs)
+	return string(data), err
+}
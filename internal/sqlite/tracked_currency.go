@@ -0,0 +1,101 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/fedorov-dmitry/go-test-api/internal"
+)
+
+type TrackedCurrencyStorage struct {
+	db *sql.DB
+}
+
+func NewTrackedCurrencyStorage(db *sql.DB) *TrackedCurrencyStorage {
+	return &TrackedCurrencyStorage{db: db}
+}
+
+func (s *TrackedCurrencyStorage) List(ctx context.Context) ([]internal.TrackedCurrency, error) {
+	query := `
+SELECT currency, added_at FROM tracked_currencies
+ORDER BY added_at, currency`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch tracked currencies: %w", err)
+	}
+
+	currencies := make([]internal.TrackedCurrency, 0)
+
+	defer rows.Close()
+
+	for rows.Next() {
+		currency := internal.TrackedCurrency{}
+		var addedAt string
+
+		err = rows.Scan(&currency.Currency, &addedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch tracked currencies: %w", err)
+		}
+
+		currency.AddedAt, err = parseTimestamp(addedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch tracked currencies: %w", err)
+		}
+
+		currencies = append(currencies, currency)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch tracked currencies: %w", err)
+	}
+
+	return currencies, nil
+}
+
+func (s *TrackedCurrencyStorage) Add(ctx context.Context, currency internal.TrackedCurrency) error {
+	query := `
+INSERT INTO tracked_currencies (currency, added_at)
+VALUES (?1, ?2)
+ON CONFLICT (currency) DO NOTHING`
+
+	result, err := s.db.ExecContext(ctx, query, currency.Currency, formatTimestamp(currency.AddedAt))
+	if err != nil {
+		return fmt.Errorf("failed to add tracked currency %s: %w", currency.Currency, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to add tracked currency %s: %w", currency.Currency, err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("failed to add tracked currency %s: %w", currency.Currency, internal.ErrCurrencyAlreadyTracked)
+	}
+
+	return nil
+}
+
+func (s *TrackedCurrencyStorage) Remove(ctx context.Context, currency internal.Currency) error {
+	query := `
+DELETE FROM tracked_currencies
+WHERE currency = ?1`
+
+	result, err := s.db.ExecContext(ctx, query, currency)
+	if err != nil {
+		return fmt.Errorf("failed to remove tracked currency %s: %w", currency, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to remove tracked currency %s: %w", currency, err)
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("failed to remove tracked currency %s: %w", currency, internal.ErrCurrencyNotTracked)
+	}
+
+	return nil
+}
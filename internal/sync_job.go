@@ -0,0 +1,157 @@
+package internal
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+var (
+	ErrSyncJobNotFound = errors.New("sync job not found")
+	ErrSyncJobRunning  = errors.New("another sync job is still running")
+)
+
+const maxSyncJobs = 100
+
+type SyncJob struct {
+	ID             string
+	Trigger        SyncTrigger
+	Status         SyncRunStatus
+	CreatedAt      time.Time
+	FinishedAt     *time.Time
+	TotalUnits     int
+	CompletedUnits int
+	FailedUnits    int
+	RowsWritten    int
+	Errors         []string
+}
+
+type SyncJobManager struct {
+	synchronizer *CurrencySynchronizer
+
+	mu   sync.Mutex
+	jobs map[string]*SyncJob
+	ids  []string
+}
+
+func NewSyncJobManager(synchronizer *CurrencySynchronizer) *SyncJobManager {
+	return &SyncJobManager{
+		synchronizer: synchronizer,
+		jobs:         make(map[string]*SyncJob),
+	}
+}
+
+func (m *SyncJobManager) Start(ctx context.Context, trigger SyncTrigger, units []SyncUnit) (SyncJob, error) {
+	id, err := newSyncJobID()
+	if err != nil {
+		return SyncJob{}, fmt.Errorf("failed to start %s sync job: %w", trigger, err)
+	}
+
+	units = m.synchronizer.Plan(units)
+
+	job := &SyncJob{
+		ID:         id,
+		Trigger:    trigger,
+		Status:     SyncRunStatusRunning,
+		CreatedAt:  time.Now().UTC(),
+		TotalUnits: len(units),
+		Errors:     make([]string, 0),
+	}
+
+	m.mu.Lock()
+	for _, running := range m.jobs {
+		if running.FinishedAt == nil {
+			m.mu.Unlock()
+			return SyncJob{}, fmt.Errorf("failed to start %s sync job: %w: %s", trigger, ErrSyncJobRunning, running.ID)
+		}
+	}
+	m.jobs[id] = job
+	m.ids = append(m.ids, id)
+	m.prune()
+	snapshot := job.snapshot()
+	m.mu.Unlock()
+
+	go func() {
+		report := m.synchronizer.RunWithProgress(ctx, trigger, units, func(result SyncUnitResult) {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+
+			job.CompletedUnits++
+			job.RowsWritten += result.RowsWritten
+			if result.Err != nil {
+				job.FailedUnits++
+				job.Errors = append(job.Errors, failureMessage(result))
+			}
+		})
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		finishedAt := time.Now().UTC()
+		job.FinishedAt = &finishedAt
+		job.Status = report.Status()
+	}()
+
+	return snapshot, nil
+}
+
+func (m *SyncJobManager) Get(id string) (SyncJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job, ok := m.jobs[id]
+	if !ok {
+		return SyncJob{}, fmt.Errorf("sync job %s: %w", id, ErrSyncJobNotFound)
+	}
+
+	return job.snapshot(), nil
+}
+
+func (m *SyncJobManager) List() []SyncJob {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	jobs := make([]SyncJob, 0, len(m.ids))
+	for i := len(m.ids) - 1; i >= 0; i-- {
+		jobs = append(jobs, m.jobs[m.ids[i]].snapshot())
+	}
+
+	return jobs
+}
+
+func (m *SyncJobManager) prune() {
+	for len(m.ids) > maxSyncJobs {
+		i := slices.IndexFunc(m.ids, func(id string) bool {
+			return m.jobs[id].FinishedAt != nil
+		})
+		if i < 0 {
+			return
+		}
+
+		delete(m.jobs, m.ids[i])
+		m.ids = slices.Delete(m.ids, i, i+1)
+	}
+}
+
+func (j *SyncJob) snapshot() SyncJob {
+	snapshot := *j
+	snapshot.Errors = slices.Clone(j.Errors)
+
+	return snapshot
+}
+
+func newSyncJobID() (string, error) {
+	b := make([]byte, 8)
+
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
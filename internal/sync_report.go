@@ -0,0 +1,83 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type SyncUnit struct {
+	Base       Currency
+	Date       time.Time
+	Currencies []Currency
+}
+
+type SyncUnitResult struct {
+	Unit        SyncUnit
+	RowsWritten int
+	Err         error
+}
+
+type SyncReport struct {
+	Succeeded []SyncUnitResult
+	Failed    []SyncUnitResult
+}
+
+func (r SyncReport) RowsWritten() int {
+	rows := 0
+	for _, result := range r.Succeeded {
+		rows += result.RowsWritten
+	}
+	for _, result := range r.Failed {
+		rows += result.RowsWritten
+	}
+
+	return rows
+}
+
+func (r SyncReport) Status() SyncRunStatus {
+	switch {
+	case len(r.Failed) == 0:
+		return SyncRunStatusSucceeded
+	case len(r.Succeeded) == 0:
+		return SyncRunStatusFailed
+	default:
+		return SyncRunStatusPartial
+	}
+}
+
+func (r SyncReport) Err() error {
+	if len(r.Failed) == 0 {
+		return nil
+	}
+
+	return &SyncError{Report: r}
+}
+
+type SyncError struct {
+	Report SyncReport
+}
+
+func (e *SyncError) Error() string {
+	failures := make([]string, len(e.Report.Failed))
+	for i, result := range e.Report.Failed {
+		failures[i] = failureMessage(result)
+	}
+
+	total := len(e.Report.Succeeded) + len(e.Report.Failed)
+
+	return fmt.Sprintf("%d of %d currency rate sync units failed: %s", len(e.Report.Failed), total, strings.Join(failures, "; "))
+}
+
+func (e *SyncError) Unwrap() []error {
+	errs := make([]error, len(e.Report.Failed))
+	for i, result := range e.Report.Failed {
+		errs[i] = result.Err
+	}
+
+	return errs
+}
+
+func failureMessage(result SyncUnitResult) string {
+	return fmt.Sprintf("%s for %s: %v", result.Unit.Base, result.Unit.Date.Format("2006-01-02"), result.Err)
+}
@@ -0,0 +1,148 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+var ErrSyncRunNotFound = errors.New("sync run not found")
+
+type SyncTrigger string
+
+const (
+	SyncTriggerStartup  SyncTrigger = "startup"
+	SyncTriggerSchedule SyncTrigger = "schedule"
+	SyncTriggerManual   SyncTrigger = "manual"
+	SyncTriggerBackfill SyncTrigger = "backfill"
+	SyncTriggerGapFill  SyncTrigger = "gapfill"
+)
+
+type SyncRunStatus string
+
+const (
+	SyncRunStatusRunning   SyncRunStatus = "running"
+	SyncRunStatusSucceeded SyncRunStatus = "succeeded"
+	SyncRunStatusPartial   SyncRunStatus = "partial"
+	SyncRunStatusFailed    SyncRunStatus = "failed"
+)
+
+type SyncRun struct {
+	ID          int64
+	Trigger     SyncTrigger
+	Status      SyncRunStatus
+	StartedAt   time.Time
+	FinishedAt  *time.Time
+	Bases       []Currency
+	Dates       []time.Time
+	RowsWritten int
+	Errors      []string
+}
+
+type SyncStatus struct {
+	LastRun           *SyncRun
+	LastSuccessfulRun *SyncRun
+}
+
+type SyncRunStorage interface {
+	Create(ctx context.Context, run SyncRun) (int64, error)
+	Update(ctx context.Context, run SyncRun) error
+	List(ctx context.Context, limit int) ([]SyncRun, error)
+	GetLastSucceeded(ctx context.Context) (SyncRun, error)
+}
+
+type SyncRunRepository struct {
+	storage SyncRunStorage
+}
+
+func NewSyncRunRepository(storage SyncRunStorage) *SyncRunRepository {
+	return &SyncRunRepository{storage: storage}
+}
+
+func (repo *SyncRunRepository) Start(ctx context.Context, trigger SyncTrigger, units []SyncUnit) (SyncRun, error) {
+	run := SyncRun{
+		Trigger:   trigger,
+		Status:    SyncRunStatusRunning,
+		StartedAt: time.Now().UTC(),
+		Bases:     make([]Currency, 0),
+		Dates:     make([]time.Time, 0),
+		Errors:    make([]string, 0),
+	}
+
+	for _, unit := range units {
+		if !slices.Contains(run.Bases, unit.Base) {
+			run.Bases = append(run.Bases, unit.Base)
+		}
+
+		day, _ := time.Parse("2006-01-02", unit.Date.Format("2006-01-02"))
+		if !slices.ContainsFunc(run.Dates, day.Equal) {
+			run.Dates = append(run.Dates, day)
+		}
+	}
+
+	slices.SortFunc(run.Dates, func(a, b time.Time) int { return a.Compare(b) })
+
+	id, err := repo.storage.Create(ctx, run)
+	if err != nil {
+		return SyncRun{}, fmt.Errorf("failed to record start of %s sync run: %w", trigger, err)
+	}
+
+	run.ID = id
+
+	return run, nil
+}
+
+func (repo *SyncRunRepository) Finish(ctx context.Context, run SyncRun, report SyncReport) (SyncRun, error) {
+	finishedAt := time.Now().UTC()
+
+	run.FinishedAt = &finishedAt
+	run.RowsWritten = report.RowsWritten()
+
+	run.Status = report.Status()
+
+	for _, result := range report.Failed {
+		run.Errors = append(run.Errors, failureMessage(result))
+	}
+
+	err := repo.storage.Update(ctx, run)
+	if err != nil {
+		return SyncRun{}, fmt.Errorf("failed to record end of sync run %d: %w", run.ID, err)
+	}
+
+	return run, nil
+}
+
+func (repo *SyncRunRepository) List(ctx context.Context, limit int) ([]SyncRun, error) {
+	runs, err := repo.storage.List(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sync runs: %w", err)
+	}
+
+	return runs, nil
+}
+
+func (repo *SyncRunRepository) Status(ctx context.Context) (SyncStatus, error) {
+	status := SyncStatus{}
+
+	runs, err := repo.storage.List(ctx, 1)
+	if err != nil {
+		return SyncStatus{}, fmt.Errorf("failed to get last sync run: %w", err)
+	}
+
+	if len(runs) > 0 {
+		status.LastRun = &runs[0]
+	}
+
+	lastSucceeded, err := repo.storage.GetLastSucceeded(ctx)
+	if err != nil && !errors.Is(err, ErrSyncRunNotFound) {
+		return SyncStatus{}, fmt.Errorf("failed to get last successful sync run: %w", err)
+	}
+
+	if err == nil {
+		status.LastSuccessfulRun = &lastSucceeded
+	}
+
+	return status, nil
+}
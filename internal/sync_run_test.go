@@ -0,0 +1,100 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fedorov-dmitry/go-test-api/internal"
+	"github.com/fedorov-dmitry/go-test-api/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSyncRunRepository_StartAndFinish(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockSyncRunStorage(ctrl)
+	repo := internal.NewSyncRunRepository(mockStorage)
+
+	ctx := context.Background()
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+	day1 := time.Date(2025, 1, 13, 10, 0, 0, 0, time.UTC)
+	day2 := time.Date(2025, 1, 14, 10, 0, 0, 0, time.UTC)
+	units := []internal.SyncUnit{{Base: usd, Date: day2}, {Base: usd, Date: day1}, {Base: eur, Date: day2}}
+
+	mockStorage.
+		EXPECT().
+		Create(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, run internal.SyncRun) (int64, error) {
+			if run.Trigger != internal.SyncTriggerStartup || run.Status != internal.SyncRunStatusRunning {
+				t.Fatalf("unexpected run: %+v", run)
+			}
+			if len(run.Bases) != 2 || run.Bases[0] != usd || run.Bases[1] != eur {
+				t.Fatalf("unexpected bases: %v", run.Bases)
+			}
+			if len(run.Dates) != 2 || run.Dates[0].Day() != 13 || run.Dates[1].Day() != 14 {
+				t.Fatalf("unexpected dates: %v", run.Dates)
+			}
+			return 7, nil
+		})
+
+	run, err := repo.Start(ctx, internal.SyncTriggerStartup, units)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if run.ID != 7 {
+		t.Fatalf("ID=%d, want 7", run.ID)
+	}
+
+	report := internal.SyncReport{
+		Succeeded: []internal.SyncUnitResult{{Unit: units[0], RowsWritten: 3}},
+		Failed:    []internal.SyncUnitResult{{Unit: units[1], RowsWritten: 1, Err: errors.New("source down")}},
+	}
+
+	mockStorage.
+		EXPECT().
+		Update(ctx, gomock.Any()).
+		Return(nil)
+
+	run, err = repo.Finish(ctx, run, report)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if run.Status != internal.SyncRunStatusPartial || run.RowsWritten != 4 || len(run.Errors) != 1 || run.FinishedAt == nil {
+		t.Fatalf("unexpected run: %+v", run)
+	}
+}
+
+func TestSyncRunRepository_Status_NoSuccessfulRun(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockSyncRunStorage(ctrl)
+	repo := internal.NewSyncRunRepository(mockStorage)
+
+	ctx := context.Background()
+
+	mockStorage.
+		EXPECT().
+		List(ctx, 1).
+		Return([]internal.SyncRun{{ID: 3, Status: internal.SyncRunStatusFailed}}, nil)
+	mockStorage.
+		EXPECT().
+		GetLastSucceeded(ctx).
+		Return(internal.SyncRun{}, internal.ErrSyncRunNotFound)
+
+	status, err := repo.Status(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status.LastRun == nil || status.LastRun.ID != 3 || status.LastSuccessfulRun != nil {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+}
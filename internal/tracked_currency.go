@@ -0,0 +1,103 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrCurrencyNotTracked     = errors.New("currency is not tracked")
+	ErrCurrencyAlreadyTracked = errors.New("currency is already tracked")
+)
+
+type TrackedCurrency struct {
+	Currency Currency
+	AddedAt  time.Time
+}
+
+type TrackedCurrencyStorage interface {
+	List(ctx context.Context) ([]TrackedCurrency, error)
+	Add(ctx context.Context, currency TrackedCurrency) error
+	Remove(ctx context.Context, currency Currency) error
+}
+
+type TrackedCurrencyRepository struct {
+	storage TrackedCurrencyStorage
+	catalog *CurrencyCatalog
+}
+
+func NewTrackedCurrencyRepository(storage TrackedCurrencyStorage, catalog *CurrencyCatalog) *TrackedCurrencyRepository {
+	return &TrackedCurrencyRepository{storage: storage, catalog: catalog}
+}
+
+func (repo *TrackedCurrencyRepository) List(ctx context.Context) ([]TrackedCurrency, error) {
+	currencies, err := repo.storage.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tracked currencies: %w", err)
+	}
+
+	return currencies, nil
+}
+
+func (repo *TrackedCurrencyRepository) Currencies(ctx context.Context) ([]Currency, error) {
+	tracked, err := repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	currencies := make([]Currency, len(tracked))
+	for i, currency := range tracked {
+		currencies[i] = currency.Currency
+	}
+
+	return currencies, nil
+}
+
+func (repo *TrackedCurrencyRepository) Seed(ctx context.Context, currencies []Currency) error {
+	tracked, err := repo.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(tracked) > 0 {
+		return nil
+	}
+
+	for _, currency := range currencies {
+		_, err = repo.Add(ctx, currency)
+		if err != nil && !errors.Is(err, ErrCurrencyAlreadyTracked) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (repo *TrackedCurrencyRepository) Add(ctx context.Context, currency Currency) (TrackedCurrency, error) {
+	if repo.catalog != nil {
+		err := repo.catalog.Validate(currency)
+		if err != nil {
+			return TrackedCurrency{}, fmt.Errorf("failed to track %s: %w", currency, err)
+		}
+	}
+
+	tracked := TrackedCurrency{Currency: currency, AddedAt: time.Now().UTC()}
+
+	err := repo.storage.Add(ctx, tracked)
+	if err != nil {
+		return TrackedCurrency{}, fmt.Errorf("failed to track %s: %w", currency, err)
+	}
+
+	return tracked, nil
+}
+
+func (repo *TrackedCurrencyRepository) Remove(ctx context.Context, currency Currency) error {
+	err := repo.storage.Remove(ctx, currency)
+	if err != nil {
+		return fmt.Errorf("failed to stop tracking %s: %w", currency, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fedorov-dmitry/go-test-api/internal"
+	"github.com/fedorov-dmitry/go-test-api/internal/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTrackedCurrencyRepository_SeedOnlyWhenEmpty(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockTrackedCurrencyStorage(ctrl)
+	repo := internal.NewTrackedCurrencyRepository(mockStorage, nil)
+
+	ctx := context.Background()
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+
+	gomock.InOrder(
+		mockStorage.EXPECT().List(ctx).Return(nil, nil),
+		mockStorage.EXPECT().Add(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, currency internal.TrackedCurrency) error {
+			if currency.Currency != usd || currency.AddedAt.IsZero() {
+				t.Fatalf("unexpected tracked currency: %+v", currency)
+			}
+			return nil
+		}),
+		mockStorage.EXPECT().Add(ctx, gomock.Any()).Return(nil),
+		mockStorage.EXPECT().List(ctx).Return([]internal.TrackedCurrency{{Currency: usd}, {Currency: eur}}, nil),
+	)
+
+	if err := repo.Seed(ctx, []internal.Currency{usd, eur}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the table is the source of truth once it has rows
+	if err := repo.Seed(ctx, []internal.Currency{usd, eur, internal.NewCurrency("jpy")}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestTrackedCurrencyRepository_AddValidatesAgainstCatalog(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCatalogStorage := mocks.NewMockCurrencyCatalogStorage(ctrl)
+	mockStorage := mocks.NewMockTrackedCurrencyStorage(ctrl)
+
+	ctx := context.Background()
+
+	mockCatalogStorage.EXPECT().Seed(ctx, gomock.Any()).Return(nil)
+	mockCatalogStorage.
+		EXPECT().
+		List(ctx).
+		Return([]internal.CurrencyInfo{
+			{Code: "chf", NumericCode: "756", Name: "Swiss Franc", MinorUnits: 2, Active: true, Kind: internal.CurrencyKindFiat},
+			{Code: "hrk", NumericCode: "191", Name: "Kuna", MinorUnits: 2, Kind: internal.CurrencyKindFiat},
+		}, nil)
+
+	catalog := internal.NewCurrencyCatalog(mockCatalogStorage)
+	if err := catalog.Load(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	repo := internal.NewTrackedCurrencyRepository(mockStorage, catalog)
+
+	if _, err := repo.Add(ctx, "hrk"); !errors.Is(err, internal.ErrInactiveCurrency) {
+		t.Fatalf("expected ErrInactiveCurrency, got %v", err)
+	}
+
+	mockStorage.EXPECT().Add(ctx, gomock.Any()).Return(internal.ErrCurrencyAlreadyTracked)
+
+	if _, err := repo.Add(ctx, "chf"); !errors.Is(err, internal.ErrCurrencyAlreadyTracked) {
+		t.Fatalf("expected ErrCurrencyAlreadyTracked, got %v", err)
+	}
+}
+
+func TestCurrencySynchronizer_ReadsTrackedCurrenciesOnEveryCall(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockTrackedCurrencyStorage(ctrl)
+	tracked := internal.NewTrackedCurrencyRepository(mockStorage, nil)
+
+	ctx := context.Background()
+	usd := internal.NewCurrency("usd")
+	eur := internal.NewCurrency("eur")
+	chf := internal.NewCurrency("chf")
+
+	s := internal.NewCurrencySynchronizer(internal.CurrencyRepository{}, nil, []internal.Currency{usd}, internal.WithTrackedCurrencies(tracked))
+
+	gomock.InOrder(
+		mockStorage.EXPECT().List(ctx).Return([]internal.TrackedCurrency{{Currency: usd}, {Currency: eur}}, nil),
+		mockStorage.EXPECT().List(ctx).Return([]internal.TrackedCurrency{{Currency: usd}, {Currency: eur}, {Currency: chf}}, nil).Times(2),
+		mockStorage.EXPECT().List(ctx).Return(nil, errors.New("connection refused")),
+	)
+
+	date := time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)
+
+	units, err := s.UnitsForRange(ctx, date, date, nil)
+	if err != nil || len(units) != 2 {
+		t.Fatalf("got %+v, %v, want 2 units", units, err)
+	}
+
+	units, err = s.UnitsForRange(ctx, date, date, nil)
+	if err != nil || len(units) != 3 {
+		t.Fatalf("got %+v, %v, want 3 units", units, err)
+	}
+
+	units, err = s.UnitsForNewCurrency(ctx, chf, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(units) != 3 {
+		t.Fatalf("expected 3 units, got %+v", units)
+	}
+	today, _ := time.Parse("2006-01-02", time.Now().Format("2006-01-02"))
+	for _, unit := range units {
+		if !unit.Date.Equal(today) {
+			t.Fatalf("expected units for %s at midnight, got %+v", today, unit)
+		}
+		if unit.Base == chf && len(unit.Currencies) != 0 {
+			t.Fatalf("expected every currency for the new base, got %+v", unit)
+		}
+		if unit.Base != chf && (len(unit.Currencies) != 1 || unit.Currencies[0] != chf) {
+			t.Fatalf("expected only the new currency for %s, got %+v", unit.Base, unit)
+		}
+	}
+
+	if _, err := s.UnitsForTodayAndLastNDays(ctx, 0); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}